@@ -0,0 +1,175 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// ReadBigUintBE reads size bytes in big-endian byte order from r and returns
+// them as a non-negative integer. ErrInvalidSize is returned if size is not
+// positive.
+func ReadBigUintBE(r io.Reader, size int) (*big.Int, error) {
+	b, err := readBig(r, size)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// ReadBigUintLE reads size bytes in little-endian byte order from r and returns
+// them as a non-negative integer. ErrInvalidSize is returned if size is not
+// positive.
+func ReadBigUintLE(r io.Reader, size int) (*big.Int, error) {
+	b, err := readBig(r, size)
+	if err != nil {
+		return nil, err
+	}
+	reverseBytes(b)
+	return new(big.Int).SetBytes(b), nil
+}
+
+// ReadBigIntBE reads size bytes in big-endian byte order from r and returns
+// them as a signed integer in two's complement representation. ErrInvalidSize
+// is returned if size is not positive.
+func ReadBigIntBE(r io.Reader, size int) (*big.Int, error) {
+	b, err := readBig(r, size)
+	if err != nil {
+		return nil, err
+	}
+	return bigFromTwos(b), nil
+}
+
+// ReadBigIntLE reads size bytes in little-endian byte order from r and returns
+// them as a signed integer in two's complement representation. ErrInvalidSize
+// is returned if size is not positive.
+func ReadBigIntLE(r io.Reader, size int) (*big.Int, error) {
+	b, err := readBig(r, size)
+	if err != nil {
+		return nil, err
+	}
+	reverseBytes(b)
+	return bigFromTwos(b), nil
+}
+
+// WriteBigUintBE writes size bytes to w that represent the non-negative integer
+// v in big-endian byte order, padded with leading zeros. ErrInvalidSize is
+// returned if size is not positive, and ErrValueOutOfRange is returned if v is
+// nil, negative, or does not fit in size bytes.
+func WriteBigUintBE(w io.Writer, v *big.Int, size int) error {
+	b, err := bigUintBytes(v, size)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// WriteBigUintLE writes size bytes to w that represent the non-negative integer
+// v in little-endian byte order, padded with trailing zeros. ErrInvalidSize is
+// returned if size is not positive, and ErrValueOutOfRange is returned if v is
+// nil, negative, or does not fit in size bytes.
+func WriteBigUintLE(w io.Writer, v *big.Int, size int) error {
+	b, err := bigUintBytes(v, size)
+	if err != nil {
+		return err
+	}
+	reverseBytes(b)
+	return write(w, b)
+}
+
+// WriteBigIntBE writes size bytes to w that represent the signed integer v in
+// two's complement representation in big-endian byte order. ErrInvalidSize is
+// returned if size is not positive, and ErrValueOutOfRange is returned if v is
+// nil or does not fit in size bytes.
+func WriteBigIntBE(w io.Writer, v *big.Int, size int) error {
+	b, err := bigIntBytes(v, size)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// WriteBigIntLE writes size bytes to w that represent the signed integer v in
+// two's complement representation in little-endian byte order. ErrInvalidSize
+// is returned if size is not positive, and ErrValueOutOfRange is returned if v
+// is nil or does not fit in size bytes.
+func WriteBigIntLE(w io.Writer, v *big.Int, size int) error {
+	b, err := bigIntBytes(v, size)
+	if err != nil {
+		return err
+	}
+	reverseBytes(b)
+	return write(w, b)
+}
+
+// readBig reads size bytes from r after checking size.
+func readBig(r io.Reader, size int) ([]byte, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("%w: %d bytes for big integer", ErrInvalidSize, size)
+	}
+	return readN(r, size)
+}
+
+// bigFromTwos returns the signed integer represented by b in two's complement
+// representation in big-endian byte order.
+func bigFromTwos(b []byte) *big.Int {
+	v := new(big.Int).SetBytes(b)
+	if b[0]&0x80 != 0 {
+		v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(len(b))*8))
+	}
+	return v
+}
+
+// bigUintBytes returns v as size bytes in big-endian byte order.
+func bigUintBytes(v *big.Int, size int) ([]byte, error) {
+	switch {
+	case size < 1:
+		return nil, fmt.Errorf("%w: %d bytes for big integer", ErrInvalidSize, size)
+	case v == nil:
+		return nil, fmt.Errorf("%w: nil big integer", ErrValueOutOfRange)
+	case v.Sign() < 0:
+		return nil, fmt.Errorf("%w: negative value %v", ErrValueOutOfRange, v)
+	case size*8 < v.BitLen():
+		return nil, fmt.Errorf("%w: %v does not fit in %d bytes", ErrValueOutOfRange, v, size)
+	}
+	return v.FillBytes(make([]byte, size)), nil
+}
+
+// bigIntBytes returns v as size bytes in two's complement representation in
+// big-endian byte order.
+func bigIntBytes(v *big.Int, size int) ([]byte, error) {
+	switch {
+	case size < 1:
+		return nil, fmt.Errorf("%w: %d bytes for big integer", ErrInvalidSize, size)
+	case v == nil:
+		return nil, fmt.Errorf("%w: nil big integer", ErrValueOutOfRange)
+	}
+	if 0 <= v.Sign() {
+		if size*8-1 < v.BitLen() {
+			return nil, fmt.Errorf("%w: %v does not fit in %d bytes", ErrValueOutOfRange, v, size)
+		}
+		return v.FillBytes(make([]byte, size)), nil
+	}
+
+	// -v-1 has the same bit length as the magnitude of v in two's complement
+	m := new(big.Int).Not(v)
+	if size*8-1 < m.BitLen() {
+		return nil, fmt.Errorf("%w: %v does not fit in %d bytes", ErrValueOutOfRange, v, size)
+	}
+	b := m.FillBytes(make([]byte, size))
+	for i := range b {
+		b[i] = ^b[i]
+	}
+	return b, nil
+}
+
+// reverseBytes reverses the order of the bytes in b in place.
+func reverseBytes(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func ExampleReadBigIntBE() {
+	b, _ := hex.DecodeString("fffffffffffffffffffffffffffffffe7fffffffffffffffffffffffffffffff")
+	r := bytes.NewReader(b)
+
+	for i := 0; i < 2; i++ {
+		v, err := typeio.ReadBigIntBE(r, 16)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(v)
+	}
+
+	// Output:
+	// -2
+	// 170141183460469231731687303715884105727
+}
+
+func ExampleWriteBigUintLE() {
+	v, _ := new(big.Int).SetString("123456789abcdef0123456789abcdef", 16)
+	w := new(bytes.Buffer)
+
+	if err := typeio.WriteBigUintLE(w, v, 32); err != nil {
+		panic(err)
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	// Output:
+	// efcdab8967452301efcdab896745230100000000000000000000000000000000
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func bigInt(t *testing.T, s string) *big.Int {
+	t.Helper()
+	v, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		t.Fatalf("invalid test data: %q", s)
+	}
+	return v
+}
+
+func reversed(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
+
+func TestReadBigUint(t *testing.T) {
+	tcs := []struct {
+		b string
+		v string
+	}{
+		{"00", "0"},
+		{"ff", "255"},
+		{"0000000000000001", "1"},
+		{"ffffffffffffffffffffffffffffffff", "0xffffffffffffffffffffffffffffffff"},
+		{"0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", "0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Fatalf("invalid test data: %q: %s", tc.b, err)
+		}
+		want := bigInt(t, tc.v)
+		v, err := typeio.ReadBigUintBE(bytes.NewReader(b), len(b))
+		if err != nil || v.Cmp(want) != 0 {
+			t.Errorf("BE: %q: unexpected read: got %v, %v, want %v", tc.b, v, err, want)
+		}
+		v, err = typeio.ReadBigUintLE(bytes.NewReader(reversed(b)), len(b))
+		if err != nil || v.Cmp(want) != 0 {
+			t.Errorf("LE: %q: unexpected read: got %v, %v, want %v", tc.b, v, err, want)
+		}
+	}
+}
+
+func TestReadBigInt(t *testing.T) {
+	tcs := []struct {
+		b string
+		v string
+	}{
+		{"00", "0"},
+		{"7f", "127"},
+		{"80", "-128"},
+		{"ff", "-1"},
+		{"ffffffffffffffffffffffffffffffff", "-1"},
+		{"80000000000000000000000000000000", "-0x80000000000000000000000000000000"},
+		{"7fffffffffffffffffffffffffffffff", "0x7fffffffffffffffffffffffffffffff"},
+		{"fffffffffffffffffffffffffffffffe", "-2"},
+		{"ff00", "-256"},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Fatalf("invalid test data: %q: %s", tc.b, err)
+		}
+		want := bigInt(t, tc.v)
+		v, err := typeio.ReadBigIntBE(bytes.NewReader(b), len(b))
+		if err != nil || v.Cmp(want) != 0 {
+			t.Errorf("BE: %q: unexpected read: got %v, %v, want %v", tc.b, v, err, want)
+		}
+		v, err = typeio.ReadBigIntLE(bytes.NewReader(reversed(b)), len(b))
+		if err != nil || v.Cmp(want) != 0 {
+			t.Errorf("LE: %q: unexpected read: got %v, %v, want %v", tc.b, v, err, want)
+		}
+	}
+}
+
+func TestReadBigInt_error(t *testing.T) {
+	if _, err := typeio.ReadBigUintBE(bytes.NewReader(nil), 16); !errors.Is(err, io.EOF) {
+		t.Errorf("unexpected error: got %v, want %v", err, io.EOF)
+	}
+	if _, err := typeio.ReadBigIntLE(bytes.NewReader(make([]byte, 15)), 16); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("unexpected error: got %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	if _, err := typeio.ReadBigIntBE(bytes.NewReader(make([]byte, 1)), 0); !errors.Is(err, typeio.ErrInvalidSize) {
+		t.Errorf("unexpected error: got %v, want %v", err, typeio.ErrInvalidSize)
+	}
+}
+
+func TestWriteBigUint(t *testing.T) {
+	tcs := []struct {
+		v    string
+		size int
+		b    string
+		e    error
+	}{
+		{"0", 1, "00", nil},
+		{"255", 1, "ff", nil},
+		{"1", 8, "0000000000000001", nil},
+		{"0xffffffffffffffffffffffffffffffff", 16, "ffffffffffffffffffffffffffffffff", nil},
+		{"0x10000", 4, "00010000", nil},
+		{"256", 1, "", typeio.ErrValueOutOfRange},
+		{"-1", 4, "", typeio.ErrValueOutOfRange},
+		{"0x100000000000000000000000000000000", 16, "", typeio.ErrValueOutOfRange},
+		{"0", 0, "", typeio.ErrInvalidSize},
+	}
+	for _, tc := range tcs {
+		v := bigInt(t, tc.v)
+		for i, f := range []func(io.Writer, *big.Int, int) error{typeio.WriteBigUintBE, typeio.WriteBigUintLE} {
+			w := new(bytes.Buffer)
+			err := f(w, v, tc.size)
+			switch {
+			case tc.e != nil && err == nil:
+				t.Errorf("%d: %s: error expected.", i, tc.v)
+			case tc.e != nil && !errors.Is(err, tc.e):
+				t.Errorf("%d: %s: unexpected type of error: got %q, want %q", i, tc.v, err, tc.e)
+			case tc.e == nil && err != nil:
+				t.Errorf("%d: %s: unexpected error: %s", i, tc.v, err)
+			}
+			got := w.Bytes()
+			if i == 1 {
+				got = reversed(got)
+			}
+			if got := hex.EncodeToString(got); got != tc.b {
+				t.Errorf("%d: %s: unexpected write: got %s, want %s", i, tc.v, got, tc.b)
+			}
+		}
+	}
+	if err := typeio.WriteBigUintBE(io.Discard, nil, 4); !errors.Is(err, typeio.ErrValueOutOfRange) {
+		t.Errorf("unexpected error: got %v, want %v", err, typeio.ErrValueOutOfRange)
+	}
+}
+
+func TestWriteBigInt(t *testing.T) {
+	tcs := []struct {
+		v    string
+		size int
+		b    string
+		e    error
+	}{
+		{"0", 1, "00", nil},
+		{"127", 1, "7f", nil},
+		{"-128", 1, "80", nil},
+		{"-1", 1, "ff", nil},
+		{"-1", 16, "ffffffffffffffffffffffffffffffff", nil},
+		{"-256", 2, "ff00", nil},
+		{"-2", 16, "fffffffffffffffffffffffffffffffe", nil},
+		{"0x7fffffffffffffffffffffffffffffff", 16, "7fffffffffffffffffffffffffffffff", nil},
+		{"-0x80000000000000000000000000000000", 16, "80000000000000000000000000000000", nil},
+		{"128", 1, "", typeio.ErrValueOutOfRange},
+		{"-129", 1, "", typeio.ErrValueOutOfRange},
+		{"0x80000000000000000000000000000000", 16, "", typeio.ErrValueOutOfRange},
+		{"-0x80000000000000000000000000000001", 16, "", typeio.ErrValueOutOfRange},
+		{"0", -1, "", typeio.ErrInvalidSize},
+	}
+	for _, tc := range tcs {
+		v := bigInt(t, tc.v)
+		for i, f := range []func(io.Writer, *big.Int, int) error{typeio.WriteBigIntBE, typeio.WriteBigIntLE} {
+			w := new(bytes.Buffer)
+			err := f(w, v, tc.size)
+			switch {
+			case tc.e != nil && err == nil:
+				t.Errorf("%d: %s: error expected.", i, tc.v)
+			case tc.e != nil && !errors.Is(err, tc.e):
+				t.Errorf("%d: %s: unexpected type of error: got %q, want %q", i, tc.v, err, tc.e)
+			case tc.e == nil && err != nil:
+				t.Errorf("%d: %s: unexpected error: %s", i, tc.v, err)
+			}
+			got := w.Bytes()
+			if i == 1 {
+				got = reversed(got)
+			}
+			if got := hex.EncodeToString(got); got != tc.b {
+				t.Errorf("%d: %s: unexpected write: got %s, want %s", i, tc.v, got, tc.b)
+			}
+		}
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio
+
+import (
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// BitOrder specifies the order in which bits are packed into each byte by
+// BitReader and BitWriter.
+type BitOrder int
+
+const (
+	// MSBFirst packs bits starting from the most significant bit of each byte.
+	// A multi-bit value is stored with its most significant bit first. This is
+	// the order used by H.264, MPEG-TS and most network protocols.
+	MSBFirst BitOrder = iota
+
+	// LSBFirst packs bits starting from the least significant bit of each
+	// byte. A multi-bit value is stored with its least significant bit first.
+	// This is the order used by DEFLATE.
+	LSBFirst
+)
+
+// BitReader reads bit fields of arbitrary length that are not necessarily
+// aligned to byte boundaries from an io.Reader.
+type BitReader struct {
+	r     io.Reader
+	order BitOrder
+	cur   byte
+	n     uint // number of unread bits in cur
+}
+
+// NewBitReader returns a new BitReader reading from r in the bit order order.
+func NewBitReader(r io.Reader, order BitOrder) *BitReader {
+	return &BitReader{r: r, order: order}
+}
+
+// ReadBits reads n bits from br and returns them as the lower n bits of a
+// uint64 value. The n must be in the range 0 to 64, otherwise ErrInvalidSize is
+// returned. It returns io.EOF only if no bits were read because the end of the
+// input was reached at a byte boundary.
+func (br *BitReader) ReadBits(n int) (uint64, error) {
+	if n < 0 || 64 < n {
+		return 0, fmt.Errorf("%w: %d bits", ErrInvalidSize, n)
+	}
+	var v uint64
+	for got := uint(0); got < uint(n); {
+		if br.n == 0 {
+			c, err := readByte(br.r)
+			if err != nil {
+				if 0 < got {
+					err = noEOF(err)
+				}
+				return 0, err
+			}
+			br.cur, br.n = c, 8
+		}
+		k := uint(n) - got
+		if br.n < k {
+			k = br.n
+		}
+		mask := byte(1<<k - 1)
+		switch br.order {
+		case LSBFirst:
+			v |= uint64(br.cur>>(8-br.n)&mask) << got
+		default:
+			v = v<<k | uint64(br.cur>>(br.n-k)&mask)
+		}
+		br.n -= k
+		got += k
+	}
+	return v, nil
+}
+
+// ReadBool reads a single bit from br and returns true if it is 1.
+func (br *BitReader) ReadBool() (bool, error) {
+	v, err := br.ReadBits(1)
+	if err != nil {
+		return false, err
+	}
+	return v == 1, nil
+}
+
+// Align discards the unread bits remaining in the current byte, so that the
+// next read starts at a byte boundary. It returns the number of bits
+// discarded.
+func (br *BitReader) Align() int {
+	n := br.n
+	br.n = 0
+	return int(n)
+}
+
+// ReadUE reads an unsigned exponential-Golomb code, ue(v) in H.264, from br.
+// ErrOverflow is returned if the value does not fit in uint64. Exp-Golomb codes
+// are defined for MSBFirst; with LSBFirst, the suffix bits following the
+// leading zero bits are read as a single LSBFirst value.
+func (br *BitReader) ReadUE() (uint64, error) {
+	lz := 0
+	for {
+		b, err := br.ReadBits(1)
+		if err != nil {
+			if 0 < lz {
+				err = noEOF(err)
+			}
+			return 0, err
+		}
+		if b == 1 {
+			break
+		}
+		if lz++; lz == 64 {
+			return 0, ErrOverflow
+		}
+	}
+	v, err := br.ReadBits(lz)
+	if err != nil {
+		return 0, noEOF(err)
+	}
+	return 1<<lz - 1 + v, nil
+}
+
+// ReadSE reads a signed exponential-Golomb code, se(v) in H.264, from br.
+// ErrOverflow is returned if the value does not fit in int64.
+func (br *BitReader) ReadSE() (int64, error) {
+	k, err := br.ReadUE()
+	if err != nil {
+		return 0, err
+	}
+	if k&1 == 1 {
+		return int64(k>>1) + 1, nil
+	}
+	return -int64(k >> 1), nil
+}
+
+// BitWriter writes bit fields of arbitrary length that are not necessarily
+// aligned to byte boundaries to an io.Writer. Bits are written to the
+// underlying io.Writer each time a byte is filled. Align must be called after
+// the last write to flush the remaining bits.
+type BitWriter struct {
+	w     io.Writer
+	order BitOrder
+	buf   [1]byte
+	n     uint // number of bits filled in buf[0]
+}
+
+// NewBitWriter returns a new BitWriter writing to w in the bit order order.
+func NewBitWriter(w io.Writer, order BitOrder) *BitWriter {
+	return &BitWriter{w: w, order: order}
+}
+
+// WriteBits writes the lower n bits of v to bw. The n must be in the range 0 to
+// 64, otherwise ErrInvalidSize is returned. ErrValueOutOfRange is returned if v
+// does not fit in n bits.
+func (bw *BitWriter) WriteBits(v uint64, n int) error {
+	if n < 0 || 64 < n {
+		return fmt.Errorf("%w: %d bits", ErrInvalidSize, n)
+	}
+	if n < 64 && v>>n != 0 {
+		return fmt.Errorf("%w: %d does not fit in %d bits", ErrValueOutOfRange, v, n)
+	}
+	for rest := uint(n); 0 < rest; {
+		k := 8 - bw.n
+		if rest < k {
+			k = rest
+		}
+		mask := uint64(1)<<k - 1
+		switch bw.order {
+		case LSBFirst:
+			bw.buf[0] |= byte(v&mask) << bw.n
+			v >>= k
+		default:
+			bw.buf[0] |= byte(v>>(rest-k)&mask) << (8 - bw.n - k)
+		}
+		bw.n += k
+		rest -= k
+		if bw.n == 8 {
+			if err := bw.flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteBool writes a single bit to bw, 1 for true and 0 for false.
+func (bw *BitWriter) WriteBool(v bool) error {
+	if v {
+		return bw.WriteBits(1, 1)
+	}
+	return bw.WriteBits(0, 1)
+}
+
+// Align pads the current byte with 0 bits up to the next byte boundary and
+// writes it to the underlying io.Writer. It does nothing if bw is already at a
+// byte boundary.
+func (bw *BitWriter) Align() error {
+	if bw.n == 0 {
+		return nil
+	}
+	return bw.flush()
+}
+
+// flush writes the current byte to the underlying io.Writer.
+func (bw *BitWriter) flush() error {
+	err := write(bw.w, bw.buf[:])
+	bw.buf[0], bw.n = 0, 0
+	return err
+}
+
+// WriteUE writes v to bw as an unsigned exponential-Golomb code, ue(v) in
+// H.264. See ReadUE for the encoding with LSBFirst. ErrValueOutOfRange is
+// returned if v is the maximum value of uint64, which can not be represented.
+func (bw *BitWriter) WriteUE(v uint64) error {
+	if v == 1<<64-1 {
+		return fmt.Errorf("%w: %d can not be exp-Golomb coded", ErrValueOutOfRange, v)
+	}
+	v++
+	lz := bits.Len64(v) - 1
+	if err := bw.WriteBits(0, lz); err != nil {
+		return err
+	}
+	if err := bw.WriteBits(1, 1); err != nil {
+		return err
+	}
+	return bw.WriteBits(v&^(1<<lz), lz)
+}
+
+// WriteSE writes v to bw as a signed exponential-Golomb code, se(v) in H.264.
+// ErrValueOutOfRange is returned if v is the minimum value of int64, which can
+// not be represented.
+func (bw *BitWriter) WriteSE(v int64) error {
+	switch {
+	case 0 < v:
+		return bw.WriteUE(uint64(v)<<1 - 1)
+	case v == -1<<63:
+		return fmt.Errorf("%w: %d can not be exp-Golomb coded", ErrValueOutOfRange, v)
+	}
+	return bw.WriteUE(uint64(-v) << 1)
+}
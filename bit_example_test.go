@@ -0,0 +1,69 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func ExampleBitReader() {
+	// MPEG-TS packet header
+	b, _ := hex.DecodeString("474011")
+	br := typeio.NewBitReader(bytes.NewReader(b), typeio.MSBFirst)
+
+	sync, _ := br.ReadBits(8)
+	tei, _ := br.ReadBool()
+	pusi, _ := br.ReadBool()
+	prio, _ := br.ReadBool()
+	pid, err := br.ReadBits(13)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("sync=%#02x tei=%t pusi=%t prio=%t pid=%#04x\n", sync, tei, pusi, prio, pid)
+
+	// Output:
+	// sync=0x47 tei=false pusi=true prio=false pid=0x0011
+}
+
+func ExampleBitWriter() {
+	w := new(bytes.Buffer)
+	bw := typeio.NewBitWriter(w, typeio.MSBFirst)
+
+	_ = bw.WriteBits(0x47, 8)
+	_ = bw.WriteBool(false)
+	_ = bw.WriteBool(true)
+	_ = bw.WriteBool(false)
+	_ = bw.WriteBits(0x11, 13)
+	_ = bw.WriteUE(3)
+	if err := bw.Align(); err != nil {
+		panic(err)
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	// Output:
+	// 47401120
+}
+
+func ExampleBitReader_ReadUE() {
+	b, _ := hex.DecodeString("a6")
+	br := typeio.NewBitReader(bytes.NewReader(b), typeio.MSBFirst)
+
+	for i := 0; i < 3; i++ {
+		v, err := br.ReadUE()
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(v)
+	}
+
+	// Output:
+	// 0
+	// 1
+	// 2
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func TestBitReader_ReadBits(t *testing.T) {
+	type field struct {
+		n int
+		v uint64
+	}
+	tcs := []struct {
+		b string
+		o typeio.BitOrder
+		f []field
+	}{
+		{"b5", typeio.MSBFirst, []field{{1, 1}, {3, 3}, {4, 5}}},
+		{"b5", typeio.LSBFirst, []field{{1, 1}, {3, 2}, {4, 0xb}}},
+		{"47140f", typeio.MSBFirst, []field{{8, 0x47}, {1, 0}, {1, 0}, {1, 0}, {13, 0x140f}}},
+		{"f0f0", typeio.MSBFirst, []field{{4, 0xf}, {8, 0x0f}, {4, 0}}},
+		{"f0f0", typeio.LSBFirst, []field{{4, 0}, {8, 0x0f}, {4, 0xf}}},
+		{"123456789abcdef0", typeio.MSBFirst, []field{{64, 0x123456789abcdef0}}},
+		{"123456789abcdef0", typeio.LSBFirst, []field{{64, 0xf0debc9a78563412}}},
+		{"12345678", typeio.MSBFirst, []field{{0, 0}, {7, 0x09}, {18, 0x68ac}, {7, 0x78}}},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		br := typeio.NewBitReader(bytes.NewReader(b), tc.o)
+		for i, f := range tc.f {
+			got, err := br.ReadBits(f.n)
+			if err != nil {
+				t.Errorf("%q, %d, #%d: unexpected error: %s", tc.b, tc.o, i, err)
+				break
+			}
+			if got != f.v {
+				t.Errorf("%q, %d, #%d: unexpected read: got %x, want %x", tc.b, tc.o, i, got, f.v)
+			}
+		}
+		if _, err := br.ReadBits(1); !errors.Is(err, io.EOF) {
+			t.Errorf("%q, %d: unexpected error at the end: got %v, want %v", tc.b, tc.o, err, io.EOF)
+		}
+	}
+}
+
+func TestBitReader_errors(t *testing.T) {
+	br := typeio.NewBitReader(bytes.NewReader([]byte{0xff}), typeio.MSBFirst)
+	if _, err := br.ReadBits(65); !errors.Is(err, typeio.ErrInvalidSize) {
+		t.Errorf("unexpected error: got %v, want %v", err, typeio.ErrInvalidSize)
+	}
+	if _, err := br.ReadBits(-1); !errors.Is(err, typeio.ErrInvalidSize) {
+		t.Errorf("unexpected error: got %v, want %v", err, typeio.ErrInvalidSize)
+	}
+	if v, err := br.ReadBool(); err != nil || !v {
+		t.Errorf("unexpected read: got %t, %v", v, err)
+	}
+	if _, err := br.ReadBits(8); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("unexpected error: got %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestBitReader_Align(t *testing.T) {
+	br := typeio.NewBitReader(bytes.NewReader([]byte{0xa5, 0x3c}), typeio.MSBFirst)
+	if n := br.Align(); n != 0 {
+		t.Errorf("unexpected bits discarded: got %d, want 0", n)
+	}
+	if v, err := br.ReadBits(3); err != nil || v != 5 {
+		t.Errorf("unexpected read: got %d, %v", v, err)
+	}
+	if n := br.Align(); n != 5 {
+		t.Errorf("unexpected bits discarded: got %d, want 5", n)
+	}
+	if v, err := br.ReadBits(8); err != nil || v != 0x3c {
+		t.Errorf("unexpected read: got %x, %v", v, err)
+	}
+}
+
+func TestBitReader_ReadUE(t *testing.T) {
+	// 1 010 011 00100 00101 0001000 000000011111111 0
+	b, _ := hex.DecodeString("a6428801fe")
+	want := []uint64{0, 1, 2, 3, 4, 7, 254}
+	br := typeio.NewBitReader(bytes.NewReader(b), typeio.MSBFirst)
+	for i, w := range want {
+		got, err := br.ReadUE()
+		if err != nil {
+			t.Fatalf("#%d: unexpected error: %s", i, err)
+		}
+		if got != w {
+			t.Errorf("#%d: unexpected read: got %d, want %d", i, got, w)
+		}
+	}
+	if _, err := br.ReadUE(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("unexpected error: got %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+
+	br = typeio.NewBitReader(bytes.NewReader(make([]byte, 9)), typeio.MSBFirst)
+	if _, err := br.ReadUE(); !errors.Is(err, typeio.ErrOverflow) {
+		t.Errorf("unexpected error: got %v, want %v", err, typeio.ErrOverflow)
+	}
+}
+
+func TestBitReader_ReadSE(t *testing.T) {
+	// 1 010 011 00100 00101 00110
+	b, _ := hex.DecodeString("a64298")
+	want := []int64{0, 1, -1, 2, -2, 3}
+	br := typeio.NewBitReader(bytes.NewReader(b), typeio.MSBFirst)
+	for i, w := range want {
+		got, err := br.ReadSE()
+		if err != nil {
+			t.Fatalf("#%d: unexpected error: %s", i, err)
+		}
+		if got != w {
+			t.Errorf("#%d: unexpected read: got %d, want %d", i, got, w)
+		}
+	}
+}
+
+func TestBitWriter_WriteBits(t *testing.T) {
+	type field struct {
+		n int
+		v uint64
+	}
+	tcs := []struct {
+		o typeio.BitOrder
+		f []field
+		b string
+	}{
+		{typeio.MSBFirst, []field{{1, 1}, {3, 3}, {4, 5}}, "b5"},
+		{typeio.LSBFirst, []field{{1, 1}, {3, 2}, {4, 0xb}}, "b5"},
+		{typeio.MSBFirst, []field{{8, 0x47}, {1, 0}, {1, 0}, {1, 0}, {13, 0x140f}}, "47140f"},
+		{typeio.LSBFirst, []field{{4, 0}, {8, 0x0f}, {4, 0xf}}, "f0f0"},
+		{typeio.MSBFirst, []field{{64, 0x123456789abcdef0}}, "123456789abcdef0"},
+		{typeio.LSBFirst, []field{{64, 0xf0debc9a78563412}}, "123456789abcdef0"},
+		{typeio.MSBFirst, []field{{3, 7}}, "e0"},
+		{typeio.LSBFirst, []field{{3, 7}}, "07"},
+		{typeio.MSBFirst, []field{{9, 0x1ff}}, "ff80"},
+		{typeio.MSBFirst, []field{{0, 0}}, ""},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		bw := typeio.NewBitWriter(w, tc.o)
+		for i, f := range tc.f {
+			if err := bw.WriteBits(f.v, f.n); err != nil {
+				t.Errorf("%d, #%d: unexpected error: %s", tc.o, i, err)
+			}
+		}
+		if err := bw.Align(); err != nil {
+			t.Errorf("%d: unexpected error: %s", tc.o, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%d: unexpected write: got %s, want %s", tc.o, got, tc.b)
+		}
+	}
+}
+
+func TestBitWriter_errors(t *testing.T) {
+	bw := typeio.NewBitWriter(new(bytes.Buffer), typeio.MSBFirst)
+	if err := bw.WriteBits(0, 65); !errors.Is(err, typeio.ErrInvalidSize) {
+		t.Errorf("unexpected error: got %v, want %v", err, typeio.ErrInvalidSize)
+	}
+	if err := bw.WriteBits(8, 3); !errors.Is(err, typeio.ErrValueOutOfRange) {
+		t.Errorf("unexpected error: got %v, want %v", err, typeio.ErrValueOutOfRange)
+	}
+	if err := bw.WriteUE(math.MaxUint64); !errors.Is(err, typeio.ErrValueOutOfRange) {
+		t.Errorf("unexpected error: got %v, want %v", err, typeio.ErrValueOutOfRange)
+	}
+	if err := bw.WriteSE(math.MinInt64); !errors.Is(err, typeio.ErrValueOutOfRange) {
+		t.Errorf("unexpected error: got %v, want %v", err, typeio.ErrValueOutOfRange)
+	}
+}
+
+func TestBitWriter_expGolomb(t *testing.T) {
+	w := new(bytes.Buffer)
+	bw := typeio.NewBitWriter(w, typeio.MSBFirst)
+	for _, v := range []uint64{0, 1, 2, 3, 4, 7, 254} {
+		if err := bw.WriteUE(v); err != nil {
+			t.Fatalf("%d: unexpected error: %s", v, err)
+		}
+	}
+	if err := bw.Align(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, want := hex.EncodeToString(w.Bytes()), "a6428801fe"; got != want {
+		t.Errorf("unexpected write: got %s, want %s", got, want)
+	}
+
+	w.Reset()
+	for _, v := range []int64{0, 1, -1, 2, -2, 3} {
+		if err := bw.WriteSE(v); err != nil {
+			t.Fatalf("%d: unexpected error: %s", v, err)
+		}
+	}
+	if err := bw.Align(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, want := hex.EncodeToString(w.Bytes()), "a64298"; got != want {
+		t.Errorf("unexpected write: got %s, want %s", got, want)
+	}
+}
+
+func TestBitWriter_roundTrip(t *testing.T) {
+	for _, o := range []typeio.BitOrder{typeio.MSBFirst, typeio.LSBFirst} {
+		uvs := []uint64{0, 5, 1<<32 + 3, math.MaxUint64 - 1}
+		svs := []int64{0, -5, 1 << 40, math.MaxInt64, math.MinInt64 + 1}
+		w := new(bytes.Buffer)
+		bw := typeio.NewBitWriter(w, o)
+		for _, v := range uvs {
+			if err := bw.WriteUE(v); err != nil {
+				t.Fatalf("%d, %d: unexpected error: %s", o, v, err)
+			}
+		}
+		for _, v := range svs {
+			if err := bw.WriteSE(v); err != nil {
+				t.Fatalf("%d, %d: unexpected error: %s", o, v, err)
+			}
+		}
+		if err := bw.Align(); err != nil {
+			t.Fatalf("%d: unexpected error: %s", o, err)
+		}
+		br := typeio.NewBitReader(w, o)
+		for _, v := range uvs {
+			if got, err := br.ReadUE(); err != nil || got != v {
+				t.Errorf("%d: unexpected read: got %d, %v, want %d", o, got, err, v)
+			}
+		}
+		for _, v := range svs {
+			if got, err := br.ReadSE(); err != nil || got != v {
+				t.Errorf("%d: unexpected read: got %d, %v, want %d", o, got, err, v)
+			}
+		}
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// ErrInvalidDNSName is the error thrown when a DNS name read or to be written
+// is not valid, for example, when a label or the whole name is too long, or
+// compression pointers form a loop.
+var ErrInvalidDNSName = errors.New("invalid DNS name")
+
+const (
+	// dnsMaxLabelLen is the maximum length of a label of a DNS name.
+	dnsMaxLabelLen = 63
+
+	// dnsMaxNameLen is the maximum length of a DNS name in the wire format,
+	// including the length bytes and the terminating zero byte.
+	dnsMaxNameLen = 255
+
+	// dnsMaxPointer is the maximum offset that a compression pointer can
+	// point to.
+	dnsMaxPointer = 0x3fff
+)
+
+// ReadDNSName reads a DNS name in the wire format defined in RFC 1035, a
+// sequence of labels each prefixed by a length byte and terminated by a zero
+// byte, from r and returns it in the presentation format with a trailing dot,
+// such as "www.example.com.". The root name is returned as ".". In labels, dots
+// and backslashes are escaped with a backslash, and bytes other than printable
+// ASCII characters are escaped as \DDD. The second return value is the number
+// of bytes read. Since r does not allow seeking back, ErrInvalidDNSName is
+// returned if the name contains a compression pointer; use ReadDNSNameAt for a
+// name in a DNS message that may be compressed.
+func ReadDNSName(r io.Reader) (string, int, error) {
+	var sb strings.Builder
+	n := 0
+	for {
+		c, err := readByte(r)
+		if err != nil {
+			if 0 < n {
+				err = noEOF(err)
+			}
+			return "", n, err
+		}
+		n++
+		switch {
+		case c == 0:
+			return dnsNameString(&sb), n, nil
+		case c&0xc0 == 0xc0:
+			return "", n, fmt.Errorf("%w: compression pointer in uncompressed name", ErrInvalidDNSName)
+		case c&0xc0 != 0:
+			return "", n, fmt.Errorf("%w: unsupported label type %#02x", ErrInvalidDNSName, c)
+		case dnsMaxNameLen < n+int(c)+1:
+			return "", n, fmt.Errorf("%w: name exceeds %d bytes", ErrInvalidDNSName, dnsMaxNameLen)
+		}
+		label, err := readN(r, int(c))
+		if err != nil {
+			return "", n, noEOF(err)
+		}
+		n += len(label)
+		appendDNSLabel(&sb, label)
+	}
+}
+
+// ReadDNSNameAt reads a DNS name in the wire format at the offset off of a DNS
+// message accessed through r, following message compression pointers. The off
+// and the compression pointers are offsets from the beginning of r, so r should
+// start at the beginning of the DNS message, for example, a bytes.Reader of the
+// whole message. The name is returned in the same format as ReadDNSName. The
+// second return value is the number of bytes the name occupies at off,
+// including the first compression pointer but not the labels it points to, so
+// that the next field starts at off plus that value. ErrInvalidDNSName is
+// returned if the name is too long or compression pointers form a loop. It
+// returns io.EOF only if off is at the end of r.
+func ReadDNSNameAt(r io.ReaderAt, off int64) (string, int, error) {
+	var sb strings.Builder
+	var buf [dnsMaxLabelLen]byte
+	pos, n, nameLen := off, 0, 0
+	jumped := false
+	visited := make(map[int64]struct{})
+	for {
+		c, err := readByteAt(r, pos)
+		if err != nil {
+			if n != 0 {
+				err = noEOF(err)
+			}
+			return "", n, err
+		}
+		pos++
+		if !jumped {
+			n++
+		}
+		switch {
+		case c == 0:
+			return dnsNameString(&sb), n, nil
+		case c&0xc0 == 0xc0:
+			lo, err := readByteAt(r, pos)
+			if err != nil {
+				return "", n, noEOF(err)
+			}
+			if !jumped {
+				n++
+			}
+			jumped = true
+			pos = int64(c&0x3f)<<8 | int64(lo)
+			if _, ok := visited[pos]; ok {
+				return "", n, fmt.Errorf("%w: compression pointer loop at %d", ErrInvalidDNSName, pos)
+			}
+			visited[pos] = struct{}{}
+			continue
+		case c&0xc0 != 0:
+			return "", n, fmt.Errorf("%w: unsupported label type %#02x", ErrInvalidDNSName, c)
+		}
+		if nameLen += 1 + int(c); dnsMaxNameLen < nameLen+1 {
+			return "", n, fmt.Errorf("%w: name exceeds %d bytes", ErrInvalidDNSName, dnsMaxNameLen)
+		}
+		label := buf[:c]
+		if err := readFull(io.NewSectionReader(r, pos, int64(c)), label); err != nil {
+			return "", n, noEOF(err)
+		}
+		pos += int64(c)
+		if !jumped {
+			n += int(c)
+		}
+		appendDNSLabel(&sb, label)
+	}
+}
+
+// readByteAt reads a single byte at the offset off of r.
+func readByteAt(r io.ReaderAt, off int64) (byte, error) {
+	var b [1]byte
+	if err := readFull(io.NewSectionReader(r, off, 1), b[:]); err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+// appendDNSLabel appends label to sb in the presentation format followed by a
+// dot.
+func appendDNSLabel(sb *strings.Builder, label []byte) {
+	for _, c := range label {
+		switch {
+		case c == '.' || c == '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case c < 0x21 || 0x7e < c:
+			fmt.Fprintf(sb, "\\%03d", c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	sb.WriteByte('.')
+}
+
+// dnsNameString returns the name built in sb, or "." for the root name.
+func dnsNameString(sb *strings.Builder) string {
+	if sb.Len() == 0 {
+		return "."
+	}
+	return sb.String()
+}
+
+// WriteDNSName writes the DNS name name to w in the wire format without
+// compression, and returns the number of bytes written. The name is in the
+// presentation format accepted by ReadDNSName, and the trailing dot is
+// optional. Both "" and "." represent the root name. ErrInvalidDNSName is
+// returned if name has an empty label, a label longer than 63 bytes, an invalid
+// escape sequence, or is longer than 255 bytes in the wire format.
+func WriteDNSName(w io.Writer, name string) (int, error) {
+	b, _, err := encodeDNSName(name)
+	if err != nil {
+		return 0, err
+	}
+	if err := write(w, b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// WriteDNSNameCompressed is identical to WriteDNSName except that it compresses
+// the name using dict, a dictionary of the names previously written in the same
+// DNS message. The off is the offset from the beginning of the message at which
+// the name is written. If a suffix of the name is found in dict, it is written
+// as a compression pointer to the offset recorded in dict. The name and its
+// suffixes written as labels are then added to dict, as long as their offsets
+// can be pointed to. The dict should be created empty for each message and
+// passed to all calls for the message; its keys are opaque to the caller. If
+// dict is nil, the name is written without compression.
+func WriteDNSNameCompressed(w io.Writer, name string, off int, dict map[string]int) (int, error) {
+	b, starts, err := encodeDNSName(name)
+	if err != nil {
+		return 0, err
+	}
+	if dict != nil {
+		cut := len(starts)
+		for i, start := range starts {
+			if _, ok := dict[string(b[start:])]; ok {
+				cut = i
+				break
+			}
+		}
+		for _, start := range starts[:cut] {
+			if 0 <= off && off+start <= dnsMaxPointer {
+				dict[string(b[start:])] = off + start
+			}
+		}
+		if cut < len(starts) {
+			start := starts[cut]
+			ptr := dict[string(b[start:])]
+			b = append(b[:start], 0xc0|byte(ptr>>8), byte(ptr))
+		}
+	}
+	if err := write(w, b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// encodeDNSName returns the wire format of name and the offsets of the labels
+// in it.
+func encodeDNSName(name string) ([]byte, []int, error) {
+	if name == "." {
+		name = ""
+	}
+	b := make([]byte, 0, len(name)+2)
+	var starts []int
+	start := 0
+	for i := 0; i < len(name); i++ {
+		if i == start {
+			starts = append(starts, len(b))
+			b = append(b, 0)
+		}
+		c := name[i]
+		switch c {
+		case '.':
+			if i == start {
+				return nil, nil, fmt.Errorf("%w: empty label in %q", ErrInvalidDNSName, name)
+			}
+			start = i + 1
+			continue
+		case '\\':
+			switch {
+			case i+1 < len(name) && (name[i+1] < '0' || '9' < name[i+1]):
+				c = name[i+1]
+				i++
+			case i+3 < len(name) && isDigits(name[i+1:i+4]):
+				v := int(name[i+1]-'0')*100 + int(name[i+2]-'0')*10 + int(name[i+3]-'0')
+				if math.MaxUint8 < v {
+					return nil, nil, fmt.Errorf("%w: invalid escape in %q", ErrInvalidDNSName, name)
+				}
+				c = byte(v)
+				i += 3
+			default:
+				return nil, nil, fmt.Errorf("%w: invalid escape in %q", ErrInvalidDNSName, name)
+			}
+		}
+		lp := starts[len(starts)-1]
+		if b[lp]++; dnsMaxLabelLen < b[lp] {
+			return nil, nil, fmt.Errorf("%w: label exceeds %d bytes in %q", ErrInvalidDNSName, dnsMaxLabelLen, name)
+		}
+		b = append(b, c)
+	}
+	b = append(b, 0)
+	if dnsMaxNameLen < len(b) {
+		return nil, nil, fmt.Errorf("%w: name exceeds %d bytes: %q", ErrInvalidDNSName, dnsMaxNameLen, name)
+	}
+	return b, starts, nil
+}
+
+// isDigits returns whether s consists of decimal digits only.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || '9' < s[i] {
+			return false
+		}
+	}
+	return true
+}
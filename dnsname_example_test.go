@@ -0,0 +1,49 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func ExampleWriteDNSNameCompressed() {
+	w := new(bytes.Buffer)
+	w.Write(make([]byte, 12)) // DNS message header
+
+	dict := make(map[string]int)
+	for _, name := range []string{"www.example.com.", "mail.example.com."} {
+		if _, err := typeio.WriteDNSNameCompressed(w, name, w.Len(), dict); err != nil {
+			panic(err)
+		}
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()[12:]))
+
+	// Output:
+	// 03777777076578616d706c6503636f6d00046d61696cc010
+}
+
+func ExampleReadDNSNameAt() {
+	msg, _ := hex.DecodeString("000000000000000000000000" +
+		"03777777076578616d706c6503636f6d00046d61696cc010")
+	r := bytes.NewReader(msg)
+
+	off := int64(12)
+	for off < int64(len(msg)) {
+		name, n, err := typeio.ReadDNSNameAt(r, off)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(off, n, name)
+		off += int64(n)
+	}
+
+	// Output:
+	// 12 17 www.example.com.
+	// 29 7 mail.example.com.
+}
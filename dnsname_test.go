@@ -0,0 +1,235 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func TestReadDNSName(t *testing.T) {
+	tcs := []struct {
+		b    string
+		name string
+		n    int
+		e    error
+	}{
+		{"03777777076578616d706c6503636f6d00", "www.example.com.", 17, nil},
+		{"00", ".", 1, nil},
+		{"03612e62015c00", `a\.b.\\.`, 7, nil},
+		{"0300ff2000", `\000\255\032.`, 5, nil},
+		{"03777777c010", "", 5, typeio.ErrInvalidDNSName},
+		{"4000", "", 1, typeio.ErrInvalidDNSName},
+		{"8000", "", 1, typeio.ErrInvalidDNSName},
+		{"", "", 0, io.EOF},
+		{"0377", "", 1, io.ErrUnexpectedEOF},
+		{"03777777", "", 4, io.ErrUnexpectedEOF},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		got, n, err := typeio.ReadDNSName(bytes.NewReader(b))
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%q: error expected: got %q", tc.b, got)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%q: unexpected type of error: got %q, want %q", tc.b, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%q: unexpected error: %s", tc.b, err)
+		case tc.e == nil && got != tc.name:
+			t.Errorf("%q: unexpected read: got %q, want %q", tc.b, got, tc.name)
+		case tc.e == nil && n != tc.n:
+			t.Errorf("%q: unexpected count: got %d, want %d", tc.b, n, tc.n)
+		}
+	}
+}
+
+func TestReadDNSName_tooLong(t *testing.T) {
+	label := "3f" + strings.Repeat("61", 63)
+	ok, _ := hex.DecodeString(strings.Repeat(label, 3) + "3d" + strings.Repeat("61", 61) + "00")
+	if _, n, err := typeio.ReadDNSName(bytes.NewReader(ok)); err != nil || n != 255 {
+		t.Errorf("255 bytes: unexpected result: %d, %v", n, err)
+	}
+	if _, _, err := typeio.ReadDNSNameAt(bytes.NewReader(ok), 0); err != nil {
+		t.Errorf("255 bytes: unexpected error: %v", err)
+	}
+	ng, _ := hex.DecodeString(strings.Repeat(label, 3) + "3e" + strings.Repeat("61", 62) + "00")
+	if _, _, err := typeio.ReadDNSName(bytes.NewReader(ng)); !errors.Is(err, typeio.ErrInvalidDNSName) {
+		t.Errorf("256 bytes: unexpected error: got %v, want %q", err, typeio.ErrInvalidDNSName)
+	}
+	if _, _, err := typeio.ReadDNSNameAt(bytes.NewReader(ng), 0); !errors.Is(err, typeio.ErrInvalidDNSName) {
+		t.Errorf("256 bytes: unexpected error: got %v, want %q", err, typeio.ErrInvalidDNSName)
+	}
+}
+
+func TestReadDNSNameAt(t *testing.T) {
+	msg, _ := hex.DecodeString(
+		"000000000000000000000000" + // header
+			"03777777076578616d706c6503636f6d00" + // 12: www.example.com.
+			"046d61696cc010" + // 29: mail + pointer to 16
+			"c00c" + // 36: pointer to 12
+			"c026" + // 38: pointer to itself
+			"0161c028" + // 40: a + pointer to 40
+			"40" + // 44: reserved label type
+			"c0ff" + // 45: pointer beyond the end
+			"056162", // 47: truncated label
+	)
+	tcs := []struct {
+		off  int64
+		name string
+		n    int
+		e    error
+	}{
+		{12, "www.example.com.", 17, nil},
+		{16, "example.com.", 13, nil},
+		{29, "mail.example.com.", 7, nil},
+		{36, "www.example.com.", 2, nil},
+		{38, "", 2, typeio.ErrInvalidDNSName},
+		{40, "", 4, typeio.ErrInvalidDNSName},
+		{44, "", 1, typeio.ErrInvalidDNSName},
+		{45, "", 2, io.ErrUnexpectedEOF},
+		{47, "", 1, io.ErrUnexpectedEOF},
+		{50, "", 0, io.EOF},
+	}
+	for _, tc := range tcs {
+		got, n, err := typeio.ReadDNSNameAt(bytes.NewReader(msg), tc.off)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%d: error expected: got %q", tc.off, got)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%d: unexpected type of error: got %q, want %q", tc.off, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%d: unexpected error: %s", tc.off, err)
+		case tc.e == nil && got != tc.name:
+			t.Errorf("%d: unexpected read: got %q, want %q", tc.off, got, tc.name)
+		case tc.e == nil && n != tc.n:
+			t.Errorf("%d: unexpected count: got %d, want %d", tc.off, n, tc.n)
+		}
+	}
+}
+
+func TestWriteDNSName(t *testing.T) {
+	long := strings.Repeat("a", 63)
+	tcs := []struct {
+		name string
+		b    string
+		e    error
+	}{
+		{"www.example.com.", "03777777076578616d706c6503636f6d00", nil},
+		{"www.example.com", "03777777076578616d706c6503636f6d00", nil},
+		{".", "00", nil},
+		{"", "00", nil},
+		{`a\.b.\\`, "03612e62015c00", nil},
+		{`\000\255\032`, "0300ff2000", nil},
+		{long + ".com", "3f" + strings.Repeat("61", 63) + "03636f6d00", nil},
+		{long + "a.com", "", typeio.ErrInvalidDNSName},
+		{strings.Repeat(long+".", 3) + long[:61], strings.Repeat("3f"+strings.Repeat("61", 63), 3) + "3d" + strings.Repeat("61", 61) + "00", nil},
+		{strings.Repeat(long+".", 3) + long[:62], "", typeio.ErrInvalidDNSName},
+		{"www..com", "", typeio.ErrInvalidDNSName},
+		{".com", "", typeio.ErrInvalidDNSName},
+		{"com..", "", typeio.ErrInvalidDNSName},
+		{`a\`, "", typeio.ErrInvalidDNSName},
+		{`a\25`, "", typeio.ErrInvalidDNSName},
+		{`a\256`, "", typeio.ErrInvalidDNSName},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		n, err := typeio.WriteDNSName(w, tc.name)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%q: error expected.", tc.name)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%q: unexpected type of error: got %q, want %q", tc.name, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%q: unexpected error: %s", tc.name, err)
+		case tc.e == nil && n != w.Len():
+			t.Errorf("%q: unexpected count: got %d, want %d", tc.name, n, w.Len())
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%q: unexpected write: got %s, want %s", tc.name, got, tc.b)
+		}
+	}
+}
+
+func TestWriteDNSNameCompressed(t *testing.T) {
+	w := new(bytes.Buffer)
+	w.Write(make([]byte, 12))
+	dict := make(map[string]int)
+	names := []struct {
+		name string
+		b    string
+	}{
+		{"www.example.com.", "03777777076578616d706c6503636f6d00"},
+		{"mail.example.com.", "046d61696cc010"},
+		{"www.example.com.", "c00c"},
+		{"example.org.", "076578616d706c65036f726700"},
+		{"smtp.mail.example.com.", "04736d7470c01d"},
+		{"com.", "c018"},
+		{".", "00"},
+	}
+	for _, tc := range names {
+		off := w.Len()
+		n, err := typeio.WriteDNSNameCompressed(w, tc.name, off, dict)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", tc.name, err)
+			continue
+		}
+		if got := hex.EncodeToString(w.Bytes()[off:]); got != tc.b {
+			t.Errorf("%q: unexpected write: got %s, want %s", tc.name, got, tc.b)
+		}
+		if n != w.Len()-off {
+			t.Errorf("%q: unexpected count: got %d, want %d", tc.name, n, w.Len()-off)
+		}
+	}
+
+	// read back all names
+	r := bytes.NewReader(w.Bytes())
+	off := int64(12)
+	for _, tc := range names {
+		got, n, err := typeio.ReadDNSNameAt(r, off)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %s", off, err)
+			break
+		}
+		if got != tc.name {
+			t.Errorf("%d: unexpected read: got %q, want %q", off, got, tc.name)
+		}
+		off += int64(n)
+	}
+
+	// offsets beyond the pointer range are not recorded
+	dict = make(map[string]int)
+	w.Reset()
+	if _, err := typeio.WriteDNSNameCompressed(w, "example.com", 0x3ffc, dict); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := typeio.WriteDNSNameCompressed(w, "com", 0x4009, dict); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := typeio.WriteDNSNameCompressed(w, "example.com", 0x400e, dict); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, want := hex.EncodeToString(w.Bytes()), "076578616d706c6503636f6d00"+"03636f6d00"+"fffc"; got != want {
+		t.Errorf("unexpected write: got %s, want %s", got, want)
+	}
+
+	// nil dict
+	w.Reset()
+	if _, err := typeio.WriteDNSNameCompressed(w, "example.com", 12, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, want := hex.EncodeToString(w.Bytes()), "076578616d706c6503636f6d00"; got != want {
+		t.Errorf("unexpected write: got %s, want %s", got, want)
+	}
+}
@@ -5,5 +5,14 @@
 /*
 Package typeio provides handy functions for reading and writing primitive data
 types over io.Reader and io.Writer.
+
+For in-memory buffers, most of the formats are also available as Append and
+Decode functions, such as AppendUint32BE and DecodeUint32BE. An Append function
+appends the encoded value to dst and returns the extended buffer, in the same
+manner as the append built-in, so that it does not allocate if dst has enough
+capacity. It returns dst as is on error. A Decode function decodes a value at
+the beginning of b and returns it along with the rest of b, without copying.
+As with the Read functions, it returns io.EOF if b is empty, and an error
+wrapping io.ErrUnexpectedEOF if b is too short. It returns b as is on error.
 */
 package typeio
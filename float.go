@@ -7,77 +7,52 @@ package typeio
 import (
 	"encoding/binary"
 	"io"
-	"math"
 )
 
 // ReadFloat32BE reads 4 bytes in big-endian byte order from r and returns them
 // as an float32 as defined in IEEE 754.
 func ReadFloat32BE(r io.Reader) (float32, error) {
-	b, err := readN(r, 4)
-	if err != nil {
-		return 0, err
-	}
-	return math.Float32frombits(binary.BigEndian.Uint32(b)), nil
+	return Read[float32](r, binary.BigEndian)
 }
 
 // WriteFloat32BE writes 4 bytes to w that represent the IEEE 754 float32 value
 // v in big-endian byte order.
 func WriteFloat32BE(w io.Writer, v float32) error {
-	b := make([]byte, 4)
-	binary.BigEndian.PutUint32(b, math.Float32bits(v))
-	return write(w, b)
+	return Write(w, v, binary.BigEndian)
 }
 
 // ReadFloat32LE reads 4 bytes in big-endian byte order from r and returns them
 // as an float32 as defined in IEEE 754.
 func ReadFloat32LE(r io.Reader) (float32, error) {
-	b, err := readN(r, 4)
-	if err != nil {
-		return 0, err
-	}
-	return math.Float32frombits(binary.LittleEndian.Uint32(b)), nil
+	return Read[float32](r, binary.LittleEndian)
 }
 
 // WriteFloat32LE writes 4 bytes to w that represent the IEEE 754 float32 value
 // v in big-endian byte order.
 func WriteFloat32LE(w io.Writer, v float32) error {
-	b := make([]byte, 4)
-	binary.LittleEndian.PutUint32(b, math.Float32bits(v))
-	return write(w, b)
+	return Write(w, v, binary.LittleEndian)
 }
 
 // ReadFloat64BE reads 8 bytes in big-endian byte order from r and returns them
 // as an float64 as defined in IEEE 754.
 func ReadFloat64BE(r io.Reader) (float64, error) {
-	b, err := readN(r, 8)
-	if err != nil {
-		return 0, err
-	}
-	return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+	return Read[float64](r, binary.BigEndian)
 }
 
 // WriteFloat64BE writes 8 bytes to w that represent the IEEE 754 float64 value
 // v in big-endian byte order.
 func WriteFloat64BE(w io.Writer, v float64) error {
-	b := make([]byte, 8)
-	binary.BigEndian.PutUint64(b, math.Float64bits(v))
-	return write(w, b)
+	return Write(w, v, binary.BigEndian)
 }
 
 // ReadFloat64LE reads 8 bytes in big-endian byte order from r and returns them
 // as an float64 as defined in IEEE 754.
 func ReadFloat64LE(r io.Reader) (float64, error) {
-	b, err := readN(r, 8)
-	if err != nil {
-		return 0, err
-	}
-	return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	return Read[float64](r, binary.LittleEndian)
 }
 
 // WriteFloat64LE writes 8 bytes to w that represent the IEEE 754 float64 value
 // v in big-endian byte order.
 func WriteFloat64LE(w io.Writer, v float64) error {
-	b := make([]byte, 8)
-	binary.LittleEndian.PutUint64(b, math.Float64bits(v))
-	return write(w, b)
+	return Write(w, v, binary.LittleEndian)
 }
@@ -0,0 +1,71 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio
+
+import "math"
+
+// AppendFloat32BE appends 4 bytes that represent the IEEE 754 float32 value v
+// in big-endian byte order to dst and returns the extended buffer.
+func AppendFloat32BE(dst []byte, v float32) []byte {
+	return AppendUint32BE(dst, math.Float32bits(v))
+}
+
+// DecodeFloat32BE decodes the first 4 bytes of b in big-endian byte order as an
+// IEEE 754 float32 value and returns it along with the rest of b.
+func DecodeFloat32BE(b []byte) (float32, []byte, error) {
+	v, rest, err := DecodeUint32BE(b)
+	if err != nil {
+		return 0, b, err
+	}
+	return math.Float32frombits(v), rest, nil
+}
+
+// AppendFloat32LE appends 4 bytes that represent the IEEE 754 float32 value v
+// in little-endian byte order to dst and returns the extended buffer.
+func AppendFloat32LE(dst []byte, v float32) []byte {
+	return AppendUint32LE(dst, math.Float32bits(v))
+}
+
+// DecodeFloat32LE decodes the first 4 bytes of b in little-endian byte order as
+// an IEEE 754 float32 value and returns it along with the rest of b.
+func DecodeFloat32LE(b []byte) (float32, []byte, error) {
+	v, rest, err := DecodeUint32LE(b)
+	if err != nil {
+		return 0, b, err
+	}
+	return math.Float32frombits(v), rest, nil
+}
+
+// AppendFloat64BE appends 8 bytes that represent the IEEE 754 float64 value v
+// in big-endian byte order to dst and returns the extended buffer.
+func AppendFloat64BE(dst []byte, v float64) []byte {
+	return AppendUint64BE(dst, math.Float64bits(v))
+}
+
+// DecodeFloat64BE decodes the first 8 bytes of b in big-endian byte order as an
+// IEEE 754 float64 value and returns it along with the rest of b.
+func DecodeFloat64BE(b []byte) (float64, []byte, error) {
+	v, rest, err := DecodeUint64BE(b)
+	if err != nil {
+		return 0, b, err
+	}
+	return math.Float64frombits(v), rest, nil
+}
+
+// AppendFloat64LE appends 8 bytes that represent the IEEE 754 float64 value v
+// in little-endian byte order to dst and returns the extended buffer.
+func AppendFloat64LE(dst []byte, v float64) []byte {
+	return AppendUint64LE(dst, math.Float64bits(v))
+}
+
+// DecodeFloat64LE decodes the first 8 bytes of b in little-endian byte order as
+// an IEEE 754 float64 value and returns it along with the rest of b.
+func DecodeFloat64LE(b []byte) (float64, []byte, error) {
+	v, rest, err := DecodeUint64LE(b)
+	if err != nil {
+		return 0, b, err
+	}
+	return math.Float64frombits(v), rest, nil
+}
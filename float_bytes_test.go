@@ -0,0 +1,21 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func TestAppendDecodeFloat(t *testing.T) {
+	testAppendDecode(t, "Float32BE", 1, "3f800000", noErr(typeio.AppendFloat32BE), typeio.DecodeFloat32BE)
+	testAppendDecode(t, "Float32LE", -2, "000000c0", noErr(typeio.AppendFloat32LE), typeio.DecodeFloat32LE)
+	testAppendDecode(t, "Float32BE", float32(math.Inf(1)), "7f800000", noErr(typeio.AppendFloat32BE), typeio.DecodeFloat32BE)
+	testAppendDecode(t, "Float64BE", math.Pi, "400921fb54442d18", noErr(typeio.AppendFloat64BE), typeio.DecodeFloat64BE)
+	testAppendDecode(t, "Float64LE", math.Pi, "182d4454fb210940", noErr(typeio.AppendFloat64LE), typeio.DecodeFloat64LE)
+	testAppendDecode(t, "Float64LE", math.Copysign(0, -1), "0000000000000080", noErr(typeio.AppendFloat64LE), typeio.DecodeFloat64LE)
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"unsafe"
+)
+
+// Number is a constraint that permits the fixed-size integer and floating-point
+// types, including the types derived from them. Integers are represented in
+// two's complement and floating-point numbers in IEEE 754 format.
+type Number interface {
+	~int8 | ~int16 | ~int32 | ~int64 |
+		~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Read reads a value of type T from r in the byte order order. The number of
+// bytes read is the size of T. If order is nil, binary.BigEndian is used.
+func Read[T Number](r io.Reader, order binary.ByteOrder) (T, error) {
+	b, err := readN(r, sizeOf[T]())
+	if err != nil {
+		return 0, err
+	}
+	return decodeNumber[T](b, order), nil
+}
+
+// Write writes the value v of type T to w in the byte order order. The number
+// of bytes written is the size of T. If order is nil, binary.BigEndian is used.
+func Write[T Number](w io.Writer, v T, order binary.ByteOrder) error {
+	b := make([]byte, sizeOf[T]())
+	encodeNumber(b, v, order)
+	return write(w, b)
+}
+
+// ReadSlice reads n values of type T from r in the byte order order and returns
+// them as a slice. If order is nil, binary.BigEndian is used. As with the other
+// functions, io.EOF is returned only if no bytes were read. ErrInvalidSize is
+// returned if n is negative.
+func ReadSlice[T Number](r io.Reader, n int, order binary.ByteOrder) ([]T, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("%w: %d elements", ErrInvalidSize, n)
+	}
+	s := make([]T, n)
+	if err := readSlice(r, s, order); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// WriteSlice writes all the values in s of type T to w in the byte order
+// order. If order is nil, binary.BigEndian is used.
+func WriteSlice[T Number](w io.Writer, s []T, order binary.ByteOrder) error {
+	return writeSlice(w, s, order)
+}
+
+// Append appends the value v of type T to dst in the byte order order and
+// returns the extended buffer. If order is nil, binary.BigEndian is used.
+func Append[T Number](dst []byte, v T, order binary.ByteOrder) []byte {
+	n := len(dst)
+	dst = append(dst, make([]byte, sizeOf[T]())...)
+	encodeNumber(dst[n:], v, order)
+	return dst
+}
+
+// Decode decodes a value of type T at the beginning of b in the byte order
+// order and returns it along with the rest of b. If order is nil,
+// binary.BigEndian is used.
+func Decode[T Number](b []byte, order binary.ByteOrder) (T, []byte, error) {
+	h, rest, err := decodeN(b, sizeOf[T]())
+	if err != nil {
+		return 0, b, err
+	}
+	return decodeNumber[T](h, order), rest, nil
+}
+
+// sizeOf returns the number of bytes of a value of type T.
+func sizeOf[T Number]() int {
+	var v T
+	return int(unsafe.Sizeof(v)) //nolint:gosec // size of a numeric type
+}
+
+// isFloat returns whether T is a floating-point type. Only a floating-point
+// type has a non-zero value for 1/2.
+func isFloat[T Number]() bool {
+	var one T = 1
+	return one/2 != 0
+}
+
+// decodeNumber returns the value of type T represented by b in the byte order
+// order. The length of b must be the size of T.
+func decodeNumber[T Number](b []byte, order binary.ByteOrder) T {
+	if order == nil {
+		order = binary.BigEndian
+	}
+	var u uint64
+	switch len(b) {
+	case 1:
+		u = uint64(b[0])
+	case 2:
+		u = uint64(order.Uint16(b))
+	case 4:
+		u = uint64(order.Uint32(b))
+	default:
+		u = order.Uint64(b)
+	}
+	switch {
+	case !isFloat[T]():
+		return T(u)
+	case len(b) == 4:
+		return T(math.Float32frombits(uint32(u)))
+	default:
+		return T(math.Float64frombits(u))
+	}
+}
+
+// encodeNumber puts the value v of type T into b in the byte order order. The
+// length of b must be the size of T.
+func encodeNumber[T Number](b []byte, v T, order binary.ByteOrder) {
+	if order == nil {
+		order = binary.BigEndian
+	}
+	var u uint64
+	switch {
+	case !isFloat[T]():
+		u = uint64(v)
+	case len(b) == 4:
+		u = uint64(math.Float32bits(float32(v)))
+	default:
+		u = math.Float64bits(float64(v))
+	}
+	switch len(b) {
+	case 1:
+		b[0] = byte(u)
+	case 2:
+		order.PutUint16(b, uint16(u))
+	case 4:
+		order.PutUint32(b, uint32(u))
+	default:
+		order.PutUint64(b, u)
+	}
+}
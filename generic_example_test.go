@@ -0,0 +1,42 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func ExampleRead() {
+	b, _ := hex.DecodeString("d2040000ffff0000803f")
+	r := bytes.NewReader(b)
+
+	u32, _ := typeio.Read[uint32](r, binary.LittleEndian)
+	i16, _ := typeio.Read[int16](r, binary.LittleEndian)
+	f32, err := typeio.Read[float32](r, binary.LittleEndian)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(u32, i16, f32)
+
+	// Output:
+	// 1234 -1 1
+}
+
+func ExampleWriteSlice() {
+	w := new(bytes.Buffer)
+
+	if err := typeio.WriteSlice(w, []uint16{1, 2, 0xabcd}, binary.BigEndian); err != nil {
+		panic(err)
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	// Output:
+	// 00010002abcd
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func testReadWrite[T typeio.Number](t *testing.T, b string, order binary.ByteOrder, v T) {
+	t.Helper()
+	d, err := hex.DecodeString(b)
+	if err != nil {
+		t.Fatalf("invalid test data: %q: %s", b, err)
+	}
+	got, err := typeio.Read[T](bytes.NewReader(d), order)
+	switch {
+	case err != nil:
+		t.Errorf("%T: %s: unexpected error: %s", v, b, err)
+	case got != v && !(got != got && v != v): // NaN
+		t.Errorf("%T: %s: unexpected read: got %v, want %v", v, b, got, v)
+	}
+	w := new(bytes.Buffer)
+	if err := typeio.Write(w, v, order); err != nil {
+		t.Errorf("%T: %v: unexpected error: %s", v, v, err)
+	}
+	if got := hex.EncodeToString(w.Bytes()); got != b {
+		t.Errorf("%T: %v: unexpected write: got %s, want %s", v, v, got, b)
+	}
+	if _, err := typeio.Read[T](bytes.NewReader(nil), order); !errors.Is(err, io.EOF) {
+		t.Errorf("%T: unexpected error: got %v, want %v", v, err, io.EOF)
+	}
+	if 1 < len(d) {
+		if _, err := typeio.Read[T](bytes.NewReader(d[1:]), order); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("%T: unexpected error: got %v, want %v", v, err, io.ErrUnexpectedEOF)
+		}
+	}
+}
+
+type testEnum uint16
+
+func TestReadWrite(t *testing.T) {
+	be, le := binary.BigEndian, binary.LittleEndian
+	testReadWrite(t, "ff", nil, uint8(255))
+	testReadWrite(t, "80", le, int8(-128))
+	testReadWrite(t, "1234", be, uint16(0x1234))
+	testReadWrite(t, "3412", le, uint16(0x1234))
+	testReadWrite(t, "fffe", be, int16(-2))
+	testReadWrite(t, "feff", le, int16(-2))
+	testReadWrite(t, "12345678", nil, uint32(0x12345678))
+	testReadWrite(t, "78563412", le, uint32(0x12345678))
+	testReadWrite(t, "80000000", be, int32(math.MinInt32))
+	testReadWrite(t, "ffffff7f", le, int32(math.MaxInt32))
+	testReadWrite(t, "0123456789abcdef", be, uint64(0x0123456789abcdef))
+	testReadWrite(t, "efcdab8967452301", le, uint64(0x0123456789abcdef))
+	testReadWrite(t, "ffffffffffffffff", be, int64(-1))
+	testReadWrite(t, "0000000000000080", le, int64(math.MinInt64))
+	testReadWrite(t, "3f800000", be, float32(1))
+	testReadWrite(t, "0000c0ff", le, math.Float32frombits(0xffc00000))
+	testReadWrite(t, "80000000", be, float32(math.Copysign(0, -1)))
+	testReadWrite(t, "400921fb54442d18", be, math.Pi)
+	testReadWrite(t, "182d4454fb210940", le, math.Pi)
+	testReadWrite(t, "fff0000000000000", be, math.Inf(-1))
+	testReadWrite(t, "0102", be, testEnum(0x0102))
+	testReadWrite(t, "0201", le, testEnum(0x0102))
+}
+
+func TestReadSlice(t *testing.T) {
+	b, _ := hex.DecodeString("000100020003fffe")
+	s, err := typeio.ReadSlice[int16](bytes.NewReader(b), 4, binary.BigEndian)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := []int16{1, 2, 3, -2}; !reflect.DeepEqual(s, want) {
+		t.Errorf("unexpected read: got %v, want %v", s, want)
+	}
+
+	f, err := typeio.ReadSlice[float32](bytes.NewReader(b), 2, binary.LittleEndian)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := []float32{math.Float32frombits(0x02000100), math.Float32frombits(0xfeff0300)}; !reflect.DeepEqual(f, want) {
+		t.Errorf("unexpected read: got %v, want %v", f, want)
+	}
+
+	if s, err := typeio.ReadSlice[uint64](bytes.NewReader(nil), 0, nil); err != nil || s == nil || len(s) != 0 {
+		t.Errorf("unexpected read: got %v, %v, want empty slice", s, err)
+	}
+	if _, err := typeio.ReadSlice[uint64](bytes.NewReader(nil), 1, nil); !errors.Is(err, io.EOF) {
+		t.Errorf("unexpected error: got %v, want %v", err, io.EOF)
+	}
+	if _, err := typeio.ReadSlice[uint32](bytes.NewReader(b), 3, nil); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("unexpected error: got %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	if _, err := typeio.ReadSlice[uint32](bytes.NewReader(b), -1, nil); !errors.Is(err, typeio.ErrInvalidSize) {
+		t.Errorf("unexpected error: got %v, want %v", err, typeio.ErrInvalidSize)
+	}
+}
+
+func TestWriteSlice(t *testing.T) {
+	tcs := []struct {
+		f func(io.Writer) error
+		b string
+	}{
+		{func(w io.Writer) error { return typeio.WriteSlice(w, []int16{1, 2, 3, -2}, binary.BigEndian) }, "000100020003fffe"},
+		{func(w io.Writer) error { return typeio.WriteSlice(w, []int16{1, 2, 3, -2}, binary.LittleEndian) }, "010002000300feff"},
+		{func(w io.Writer) error { return typeio.WriteSlice(w, []uint8{1, 2, 3}, nil) }, "010203"},
+		{func(w io.Writer) error { return typeio.WriteSlice(w, []float64{1, -2}, nil) }, "3ff0000000000000c000000000000000"},
+		{func(w io.Writer) error { return typeio.WriteSlice[uint32](w, nil, nil) }, ""},
+	}
+	for i, tc := range tcs {
+		w := new(bytes.Buffer)
+		if err := tc.f(w); err != nil {
+			t.Errorf("#%d: unexpected error: %s", i, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("#%d: unexpected write: got %s, want %s", i, got, tc.b)
+		}
+	}
+}
@@ -6,249 +6,685 @@ package typeio
 
 import (
 	"encoding/binary"
+	"errors"
 	"io"
 )
 
+// ErrValueOutOfRange is the error thrown when a value to be written can not be
+// represented in the specified format.
+var ErrValueOutOfRange = errors.New("value out of range")
+
+// ErrInvalidSize is the error thrown when an unsupported size is specified for
+// a format that allows multiple sizes.
+var ErrInvalidSize = errors.New("invalid size")
+
 // ReadUint8 reads 1 byte from r and returns it as a uint8 value.
 func ReadUint8(r io.Reader) (uint8, error) {
-	b, err := readN(r, 1)
-	if err != nil {
-		return 0, err
-	}
-	return b[0], nil
+	return Read[uint8](r, nil)
 }
 
 // WriteUint8 writes 1 byte to w that represents the value v of uint8.
 func WriteUint8(w io.Writer, v uint8) error {
-	return write(w, []byte{v})
+	return Write(w, v, nil)
 }
 
 // ReadInt8 reads 1 byte from r and returns it as an int8 value.
 func ReadInt8(r io.Reader) (int8, error) {
-	b, err := readN(r, 1)
-	if err != nil {
-		return 0, err
-	}
-	return int8(b[0]), nil
+	return Read[int8](r, nil)
 }
 
 // WriteInt8 writes 1 byte to w that represents the value v of int8.
 func WriteInt8(w io.Writer, v int8) error {
-	return write(w, []byte{uint8(v)})
+	return Write(w, v, nil)
 }
 
 // ReadUint16BE reads 2 bytes in big-endian byte order from r and returns them
 // as a uint16 value.
 func ReadUint16BE(r io.Reader) (uint16, error) {
-	b, err := readN(r, 2)
-	if err != nil {
-		return 0, err
-	}
-	return binary.BigEndian.Uint16(b), nil
+	return Read[uint16](r, binary.BigEndian)
 }
 
 // WriteUint16BE writes 2 bytes to w that represent the value v of uint16 in
 // big-endian byte order.
 func WriteUint16BE(w io.Writer, v uint16) error {
-	b := make([]byte, 2)
-	binary.BigEndian.PutUint16(b, v)
-	return write(w, b)
+	return Write(w, v, binary.BigEndian)
 }
 
 // ReadUint16LE reads 2 bytes in little-endian byte order from r and returns
 // them as a uint16 value.
 func ReadUint16LE(r io.Reader) (uint16, error) {
-	b, err := readN(r, 2)
-	if err != nil {
-		return 0, err
-	}
-	return binary.LittleEndian.Uint16(b), nil
+	return Read[uint16](r, binary.LittleEndian)
 }
 
 // WriteUint16LE writes 2 bytes to w that represent the value v of uint16 in
 // little-endian byte order.
 func WriteUint16LE(w io.Writer, v uint16) error {
-	b := make([]byte, 2)
-	binary.LittleEndian.PutUint16(b, v)
-	return write(w, b)
+	return Write(w, v, binary.LittleEndian)
 }
 
 // ReadInt16BE reads 2 bytes in big-endian byte order from r and returns them as
 // an int16 value.
 func ReadInt16BE(r io.Reader) (int16, error) {
-	b, err := readN(r, 2)
-	if err != nil {
-		return 0, err
-	}
-	return int16(binary.BigEndian.Uint16(b)), nil
+	return Read[int16](r, binary.BigEndian)
 }
 
 // WriteInt16BE writes 2 bytes to w that represent the value v of int16 in
 // big-endian byte order.
 func WriteInt16BE(w io.Writer, v int16) error {
-	b := make([]byte, 2)
-	binary.BigEndian.PutUint16(b, uint16(v))
-	return write(w, b)
+	return Write(w, v, binary.BigEndian)
 }
 
 // ReadInt16LE reads 2 bytes in little-endian byte order from r and returns them
 // as an int16 value.
 func ReadInt16LE(r io.Reader) (int16, error) {
-	b, err := readN(r, 2)
-	if err != nil {
-		return 0, err
-	}
-	return int16(binary.LittleEndian.Uint16(b)), nil
+	return Read[int16](r, binary.LittleEndian)
 }
 
 // WriteInt16LE writes 2 bytes to w that represent the value v of int16 in
 // little-endian byte order.
 func WriteInt16LE(w io.Writer, v int16) error {
-	b := make([]byte, 2)
-	binary.LittleEndian.PutUint16(b, uint16(v))
-	return write(w, b)
+	return Write(w, v, binary.LittleEndian)
 }
 
 // ReadUint32BE reads 4 bytes in big-endian byte order from r and returns them
 // as a uint32 value.
 func ReadUint32BE(r io.Reader) (uint32, error) {
-	b, err := readN(r, 4)
-	if err != nil {
-		return 0, err
-	}
-	return binary.BigEndian.Uint32(b), nil
+	return Read[uint32](r, binary.BigEndian)
 }
 
 // WriteUint32BE writes 4 bytes to w that represent the value v of uint32 in
 // big-endian byte order.
 func WriteUint32BE(w io.Writer, v uint32) error {
-	b := make([]byte, 4)
-	binary.BigEndian.PutUint32(b, v)
-	return write(w, b)
+	return Write(w, v, binary.BigEndian)
 }
 
 // ReadUint32LE reads 4 bytes in little-endian byte order from r and returns
 // them as a uint32 value.
 func ReadUint32LE(r io.Reader) (uint32, error) {
-	b, err := readN(r, 4)
-	if err != nil {
-		return 0, err
-	}
-	return binary.LittleEndian.Uint32(b), nil
+	return Read[uint32](r, binary.LittleEndian)
 }
 
 // WriteUint32LE writes 4 bytes to w that represent the value v of uint32 in
 // little-endian byte order.
 func WriteUint32LE(w io.Writer, v uint32) error {
-	b := make([]byte, 4)
-	binary.LittleEndian.PutUint32(b, v)
-	return write(w, b)
+	return Write(w, v, binary.LittleEndian)
 }
 
 // ReadInt32BE reads 4 bytes in big-endian byte order from r and returns them as
 // an int32 value.
 func ReadInt32BE(r io.Reader) (int32, error) {
-	b, err := readN(r, 4)
-	if err != nil {
-		return 0, err
-	}
-	return int32(binary.BigEndian.Uint32(b)), nil
+	return Read[int32](r, binary.BigEndian)
 }
 
 // WriteInt32BE writes 4 bytes to w that represent the value v of int32 in
 // big-endian byte order.
 func WriteInt32BE(w io.Writer, v int32) error {
-	b := make([]byte, 4)
-	binary.BigEndian.PutUint32(b, uint32(v))
-	return write(w, b)
+	return Write(w, v, binary.BigEndian)
 }
 
 // ReadInt32LE reads 4 bytes in little-endian byte order from r and returns them
 // as an int32 value.
 func ReadInt32LE(r io.Reader) (int32, error) {
-	b, err := readN(r, 4)
-	if err != nil {
-		return 0, err
-	}
-	return int32(binary.LittleEndian.Uint32(b)), nil
+	return Read[int32](r, binary.LittleEndian)
 }
 
 // WriteInt32LE writes 4 bytes to w that represent the value v of int32 in
 // little-endian byte order.
 func WriteInt32LE(w io.Writer, v int32) error {
-	b := make([]byte, 4)
-	binary.LittleEndian.PutUint32(b, uint32(v))
-	return write(w, b)
+	return Write(w, v, binary.LittleEndian)
 }
 
 // ReadUint64BE reads 8 bytes in big-endian byte order from r and returns them
 // as a uint64 value.
 func ReadUint64BE(r io.Reader) (uint64, error) {
-	b, err := readN(r, 8)
-	if err != nil {
-		return 0, err
-	}
-	return binary.BigEndian.Uint64(b), nil
+	return Read[uint64](r, binary.BigEndian)
 }
 
 // WriteUint64BE writes 8 bytes to w that represent the value v of uint64 in
 // big-endian byte order.
 func WriteUint64BE(w io.Writer, v uint64) error {
-	b := make([]byte, 8)
-	binary.BigEndian.PutUint64(b, v)
-	return write(w, b)
+	return Write(w, v, binary.BigEndian)
 }
 
 // ReadUint64LE reads 8 bytes in little-endian byte order from r and returns
 // them as a uint64 value.
 func ReadUint64LE(r io.Reader) (uint64, error) {
-	b, err := readN(r, 8)
-	if err != nil {
-		return 0, err
-	}
-	return binary.LittleEndian.Uint64(b), nil
+	return Read[uint64](r, binary.LittleEndian)
 }
 
 // WriteUint64LE writes 8 bytes to w that represent the value v of uint64 in
 // little-endian byte order.
 func WriteUint64LE(w io.Writer, v uint64) error {
-	b := make([]byte, 8)
-	binary.LittleEndian.PutUint64(b, v)
-	return write(w, b)
+	return Write(w, v, binary.LittleEndian)
 }
 
 // ReadInt64BE reads 8 bytes in big-endian byte order from r and returns them as
 // an int64 value.
 func ReadInt64BE(r io.Reader) (int64, error) {
-	b, err := readN(r, 8)
-	if err != nil {
-		return 0, err
-	}
-	return int64(binary.BigEndian.Uint64(b)), nil
+	return Read[int64](r, binary.BigEndian)
 }
 
 // WriteInt64BE writes 8 bytes to w that represent the value v of int64 in
 // big-endian byte order.
 func WriteInt64BE(w io.Writer, v int64) error {
-	b := make([]byte, 8)
-	binary.BigEndian.PutUint64(b, uint64(v))
-	return write(w, b)
+	return Write(w, v, binary.BigEndian)
 }
 
 // ReadInt64LE reads 8 bytes in little-endian byte order from r and returns them
 // as an int64 value.
 func ReadInt64LE(r io.Reader) (int64, error) {
-	b, err := readN(r, 8)
+	return Read[int64](r, binary.LittleEndian)
+}
+
+// WriteInt64LE writes 8 bytes to w that represent the value v of int64 in
+// little-endian byte order.
+func WriteInt64LE(w io.Writer, v int64) error {
+	return Write(w, v, binary.LittleEndian)
+}
+
+// ReadUint24BE reads 3 bytes in big-endian byte order from r and returns
+// them as a uint32 value.
+func ReadUint24BE(r io.Reader) (uint32, error) {
+	b, err := readN(r, 3)
 	if err != nil {
 		return 0, err
 	}
-	return int64(binary.LittleEndian.Uint64(b)), nil
+	return uint32(uintBE(b)), nil
 }
 
-// WriteInt64LE writes 8 bytes to w that represent the value v of int64 in
+// WriteUint24BE writes 3 bytes to w that represent the value v in
+// big-endian byte order. ErrValueOutOfRange is returned if v does not fit in 24
+// bits.
+func WriteUint24BE(w io.Writer, v uint32) error {
+	b, err := AppendUint24BE(nil, v)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadUint24LE reads 3 bytes in little-endian byte order from r and returns
+// them as a uint32 value.
+func ReadUint24LE(r io.Reader) (uint32, error) {
+	b, err := readN(r, 3)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(uintLE(b)), nil
+}
+
+// WriteUint24LE writes 3 bytes to w that represent the value v in
+// little-endian byte order. ErrValueOutOfRange is returned if v does not fit in
+// 24 bits.
+func WriteUint24LE(w io.Writer, v uint32) error {
+	b, err := AppendUint24LE(nil, v)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadInt24BE reads 3 bytes in big-endian byte order from r, interprets
+// them as a 24-bit two's complement signed integer, and returns it
+// sign-extended to an int32 value.
+func ReadInt24BE(r io.Reader) (int32, error) {
+	b, err := readN(r, 3)
+	if err != nil {
+		return 0, err
+	}
+	return int32(signExtend(uintBE(b), 24)), nil
+}
+
+// WriteInt24BE writes 3 bytes to w that represent the value v as a
+// 24-bit two's complement signed integer in big-endian byte order.
+// ErrValueOutOfRange is returned if v does not fit in 24 bits.
+func WriteInt24BE(w io.Writer, v int32) error {
+	b, err := AppendInt24BE(nil, v)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadInt24LE reads 3 bytes in little-endian byte order from r, interprets
+// them as a 24-bit two's complement signed integer, and returns it
+// sign-extended to an int32 value.
+func ReadInt24LE(r io.Reader) (int32, error) {
+	b, err := readN(r, 3)
+	if err != nil {
+		return 0, err
+	}
+	return int32(signExtend(uintLE(b), 24)), nil
+}
+
+// WriteInt24LE writes 3 bytes to w that represent the value v as a
+// 24-bit two's complement signed integer in little-endian byte order.
+// ErrValueOutOfRange is returned if v does not fit in 24 bits.
+func WriteInt24LE(w io.Writer, v int32) error {
+	b, err := AppendInt24LE(nil, v)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadUint40BE reads 5 bytes in big-endian byte order from r and returns
+// them as a uint64 value.
+func ReadUint40BE(r io.Reader) (uint64, error) {
+	b, err := readN(r, 5)
+	if err != nil {
+		return 0, err
+	}
+	return uintBE(b), nil
+}
+
+// WriteUint40BE writes 5 bytes to w that represent the value v in
+// big-endian byte order. ErrValueOutOfRange is returned if v does not fit in 40
+// bits.
+func WriteUint40BE(w io.Writer, v uint64) error {
+	b, err := AppendUint40BE(nil, v)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadUint40LE reads 5 bytes in little-endian byte order from r and returns
+// them as a uint64 value.
+func ReadUint40LE(r io.Reader) (uint64, error) {
+	b, err := readN(r, 5)
+	if err != nil {
+		return 0, err
+	}
+	return uintLE(b), nil
+}
+
+// WriteUint40LE writes 5 bytes to w that represent the value v in
+// little-endian byte order. ErrValueOutOfRange is returned if v does not fit in
+// 40 bits.
+func WriteUint40LE(w io.Writer, v uint64) error {
+	b, err := AppendUint40LE(nil, v)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadInt40BE reads 5 bytes in big-endian byte order from r, interprets
+// them as a 40-bit two's complement signed integer, and returns it
+// sign-extended to an int64 value.
+func ReadInt40BE(r io.Reader) (int64, error) {
+	b, err := readN(r, 5)
+	if err != nil {
+		return 0, err
+	}
+	return signExtend(uintBE(b), 40), nil
+}
+
+// WriteInt40BE writes 5 bytes to w that represent the value v as a
+// 40-bit two's complement signed integer in big-endian byte order.
+// ErrValueOutOfRange is returned if v does not fit in 40 bits.
+func WriteInt40BE(w io.Writer, v int64) error {
+	b, err := AppendInt40BE(nil, v)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadInt40LE reads 5 bytes in little-endian byte order from r, interprets
+// them as a 40-bit two's complement signed integer, and returns it
+// sign-extended to an int64 value.
+func ReadInt40LE(r io.Reader) (int64, error) {
+	b, err := readN(r, 5)
+	if err != nil {
+		return 0, err
+	}
+	return signExtend(uintLE(b), 40), nil
+}
+
+// WriteInt40LE writes 5 bytes to w that represent the value v as a
+// 40-bit two's complement signed integer in little-endian byte order.
+// ErrValueOutOfRange is returned if v does not fit in 40 bits.
+func WriteInt40LE(w io.Writer, v int64) error {
+	b, err := AppendInt40LE(nil, v)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadUint48BE reads 6 bytes in big-endian byte order from r and returns
+// them as a uint64 value.
+func ReadUint48BE(r io.Reader) (uint64, error) {
+	b, err := readN(r, 6)
+	if err != nil {
+		return 0, err
+	}
+	return uintBE(b), nil
+}
+
+// WriteUint48BE writes 6 bytes to w that represent the value v in
+// big-endian byte order. ErrValueOutOfRange is returned if v does not fit in 48
+// bits.
+func WriteUint48BE(w io.Writer, v uint64) error {
+	b, err := AppendUint48BE(nil, v)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadUint48LE reads 6 bytes in little-endian byte order from r and returns
+// them as a uint64 value.
+func ReadUint48LE(r io.Reader) (uint64, error) {
+	b, err := readN(r, 6)
+	if err != nil {
+		return 0, err
+	}
+	return uintLE(b), nil
+}
+
+// WriteUint48LE writes 6 bytes to w that represent the value v in
+// little-endian byte order. ErrValueOutOfRange is returned if v does not fit in
+// 48 bits.
+func WriteUint48LE(w io.Writer, v uint64) error {
+	b, err := AppendUint48LE(nil, v)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadInt48BE reads 6 bytes in big-endian byte order from r, interprets
+// them as a 48-bit two's complement signed integer, and returns it
+// sign-extended to an int64 value.
+func ReadInt48BE(r io.Reader) (int64, error) {
+	b, err := readN(r, 6)
+	if err != nil {
+		return 0, err
+	}
+	return signExtend(uintBE(b), 48), nil
+}
+
+// WriteInt48BE writes 6 bytes to w that represent the value v as a
+// 48-bit two's complement signed integer in big-endian byte order.
+// ErrValueOutOfRange is returned if v does not fit in 48 bits.
+func WriteInt48BE(w io.Writer, v int64) error {
+	b, err := AppendInt48BE(nil, v)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadInt48LE reads 6 bytes in little-endian byte order from r, interprets
+// them as a 48-bit two's complement signed integer, and returns it
+// sign-extended to an int64 value.
+func ReadInt48LE(r io.Reader) (int64, error) {
+	b, err := readN(r, 6)
+	if err != nil {
+		return 0, err
+	}
+	return signExtend(uintLE(b), 48), nil
+}
+
+// WriteInt48LE writes 6 bytes to w that represent the value v as a
+// 48-bit two's complement signed integer in little-endian byte order.
+// ErrValueOutOfRange is returned if v does not fit in 48 bits.
+func WriteInt48LE(w io.Writer, v int64) error {
+	b, err := AppendInt48LE(nil, v)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadUint56BE reads 7 bytes in big-endian byte order from r and returns
+// them as a uint64 value.
+func ReadUint56BE(r io.Reader) (uint64, error) {
+	b, err := readN(r, 7)
+	if err != nil {
+		return 0, err
+	}
+	return uintBE(b), nil
+}
+
+// WriteUint56BE writes 7 bytes to w that represent the value v in
+// big-endian byte order. ErrValueOutOfRange is returned if v does not fit in 56
+// bits.
+func WriteUint56BE(w io.Writer, v uint64) error {
+	b, err := AppendUint56BE(nil, v)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadUint56LE reads 7 bytes in little-endian byte order from r and returns
+// them as a uint64 value.
+func ReadUint56LE(r io.Reader) (uint64, error) {
+	b, err := readN(r, 7)
+	if err != nil {
+		return 0, err
+	}
+	return uintLE(b), nil
+}
+
+// WriteUint56LE writes 7 bytes to w that represent the value v in
+// little-endian byte order. ErrValueOutOfRange is returned if v does not fit in
+// 56 bits.
+func WriteUint56LE(w io.Writer, v uint64) error {
+	b, err := AppendUint56LE(nil, v)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadInt56BE reads 7 bytes in big-endian byte order from r, interprets
+// them as a 56-bit two's complement signed integer, and returns it
+// sign-extended to an int64 value.
+func ReadInt56BE(r io.Reader) (int64, error) {
+	b, err := readN(r, 7)
+	if err != nil {
+		return 0, err
+	}
+	return signExtend(uintBE(b), 56), nil
+}
+
+// WriteInt56BE writes 7 bytes to w that represent the value v as a
+// 56-bit two's complement signed integer in big-endian byte order.
+// ErrValueOutOfRange is returned if v does not fit in 56 bits.
+func WriteInt56BE(w io.Writer, v int64) error {
+	b, err := AppendInt56BE(nil, v)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadInt56LE reads 7 bytes in little-endian byte order from r, interprets
+// them as a 56-bit two's complement signed integer, and returns it
+// sign-extended to an int64 value.
+func ReadInt56LE(r io.Reader) (int64, error) {
+	b, err := readN(r, 7)
+	if err != nil {
+		return 0, err
+	}
+	return signExtend(uintLE(b), 56), nil
+}
+
+// WriteInt56LE writes 7 bytes to w that represent the value v as a
+// 56-bit two's complement signed integer in little-endian byte order.
+// ErrValueOutOfRange is returned if v does not fit in 56 bits.
+func WriteInt56LE(w io.Writer, v int64) error {
+	b, err := AppendInt56LE(nil, v)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// Uint128 represents a 128-bit unsigned integer as a pair of uint64 values.
+type Uint128 struct {
+	Hi uint64 // upper 64 bits
+	Lo uint64 // lower 64 bits
+}
+
+// ReadUint128BE reads 16 bytes in big-endian byte order from r and returns
+// them as a Uint128 value.
+func ReadUint128BE(r io.Reader) (Uint128, error) {
+	b, err := readN(r, 16)
+	if err != nil {
+		return Uint128{}, err
+	}
+	return Uint128{
+		Hi: binary.BigEndian.Uint64(b[:8]),
+		Lo: binary.BigEndian.Uint64(b[8:]),
+	}, nil
+}
+
+// WriteUint128BE writes 16 bytes to w that represent the value v of Uint128 in
+// big-endian byte order.
+func WriteUint128BE(w io.Writer, v Uint128) error {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b[:8], v.Hi)
+	binary.BigEndian.PutUint64(b[8:], v.Lo)
+	return write(w, b)
+}
+
+// ReadUint128LE reads 16 bytes in little-endian byte order from r and returns
+// them as a Uint128 value.
+func ReadUint128LE(r io.Reader) (Uint128, error) {
+	b, err := readN(r, 16)
+	if err != nil {
+		return Uint128{}, err
+	}
+	return Uint128{
+		Hi: binary.LittleEndian.Uint64(b[8:]),
+		Lo: binary.LittleEndian.Uint64(b[:8]),
+	}, nil
+}
+
+// WriteUint128LE writes 16 bytes to w that represent the value v of Uint128 in
 // little-endian byte order.
-func WriteInt64LE(w io.Writer, v int64) error {
-	b := make([]byte, 8)
-	binary.LittleEndian.PutUint64(b, uint64(v))
+func WriteUint128LE(w io.Writer, v Uint128) error {
+	b := make([]byte, 16)
+	binary.LittleEndian.PutUint64(b[:8], v.Lo)
+	binary.LittleEndian.PutUint64(b[8:], v.Hi)
 	return write(w, b)
 }
+
+// uintBE returns the unsigned integer represented by b in big-endian byte
+// order. The length of b must not exceed 8.
+func uintBE(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
+
+// uintLE returns the unsigned integer represented by b in little-endian byte
+// order. The length of b must not exceed 8.
+func uintLE(b []byte) uint64 {
+	var v uint64
+	for i := len(b) - 1; 0 <= i; i-- {
+		v = v<<8 | uint64(b[i])
+	}
+	return v
+}
+
+// putUintBE puts the lower len(b) bytes of v into b in big-endian byte order
+// and returns b.
+func putUintBE(b []byte, v uint64) []byte {
+	for i := len(b) - 1; 0 <= i; i-- {
+		b[i] = byte(v)
+		v >>= 8
+	}
+	return b
+}
+
+// putUintLE puts the lower len(b) bytes of v into b in little-endian byte
+// order and returns b.
+func putUintLE(b []byte, v uint64) []byte {
+	for i := range b {
+		b[i] = byte(v)
+		v >>= 8
+	}
+	return b
+}
+
+// signExtend returns the lower bits bits of v as a two's complement signed
+// integer.
+func signExtend(v uint64, bits int) int64 {
+	s := 64 - bits
+	return int64(v<<s) >> s
+}
+
+// MaxQUICVarint is the maximum value that can be represented as a QUIC
+// variable-length integer, 2^62-1.
+const MaxQUICVarint = 1<<62 - 1
+
+// ReadQUICVarint reads a variable-length integer defined in RFC 9000 section 16
+// from r and returns it as a uint64 value. The second return value is the
+// number of bytes read, which is one of 1, 2, 4 or 8. Non-minimal encodings are
+// accepted.
+func ReadQUICVarint(r io.Reader) (uint64, int, error) {
+	c, err := readByte(r)
+	if err != nil {
+		return 0, 0, err
+	}
+	n := 1 << (c >> 6)
+	v := uint64(c & 0x3f)
+	if n == 1 {
+		return v, 1, nil
+	}
+	var b [7]byte
+	if err := readFull(r, b[:n-1]); err != nil {
+		return 0, 1, noEOF(err)
+	}
+	for _, c := range b[:n-1] {
+		v = v<<8 | uint64(c)
+	}
+	return v, n, nil
+}
+
+// WriteQUICVarint writes the value v of uint64 to w as a variable-length
+// integer defined in RFC 9000 section 16, using the minimum number of bytes. It
+// returns the number of bytes written. ErrValueOutOfRange is returned if v
+// exceeds MaxQUICVarint.
+func WriteQUICVarint(w io.Writer, v uint64) (int, error) {
+	return WriteQUICVarintN(w, v, quicVarintLen(v))
+}
+
+// WriteQUICVarintN is identical to WriteQUICVarint except that it always
+// writes the value using the encoding of n bytes, even if a shorter encoding is
+// available. The n must be one of 1, 2, 4 or 8, otherwise ErrInvalidSize is
+// returned. ErrValueOutOfRange is returned if v does not fit in n bytes.
+func WriteQUICVarintN(w io.Writer, v uint64, n int) (int, error) {
+	var a [8]byte
+	b, err := AppendQUICVarintN(a[:0], v, n)
+	if err != nil {
+		return 0, err
+	}
+	if err := write(w, b); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// quicVarintLen returns the minimum number of bytes to encode v as a QUIC
+// variable-length integer. It returns 8 for a value that exceeds MaxQUICVarint.
+func quicVarintLen(v uint64) int {
+	switch {
+	case v <= 0x3f:
+		return 1
+	case v <= 0x3fff:
+		return 2
+	case v <= 0x3fffffff:
+		return 4
+	}
+	return 8
+}
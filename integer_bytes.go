@@ -0,0 +1,672 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// AppendUint8 appends 1 byte that represents the value v of uint8 to dst and
+// returns the extended buffer.
+func AppendUint8(dst []byte, v uint8) []byte {
+	return append(dst, v)
+}
+
+// DecodeUint8 decodes the first byte of b as a uint8 value and returns it along
+// with the rest of b.
+func DecodeUint8(b []byte) (uint8, []byte, error) {
+	h, rest, err := decodeN(b, 1)
+	if err != nil {
+		return 0, b, err
+	}
+	return h[0], rest, nil
+}
+
+// AppendInt8 appends 1 byte that represents the value v of int8 to dst and
+// returns the extended buffer.
+func AppendInt8(dst []byte, v int8) []byte {
+	return append(dst, uint8(v))
+}
+
+// DecodeInt8 decodes the first byte of b as an int8 value and returns it along
+// with the rest of b.
+func DecodeInt8(b []byte) (int8, []byte, error) {
+	h, rest, err := decodeN(b, 1)
+	if err != nil {
+		return 0, b, err
+	}
+	return int8(h[0]), rest, nil
+}
+
+// AppendUint16BE appends 2 bytes that represent the value v of uint16 in
+// big-endian byte order to dst and returns the extended buffer.
+func AppendUint16BE(dst []byte, v uint16) []byte {
+	return append(dst, byte(v>>8), byte(v))
+}
+
+// DecodeUint16BE decodes the first 2 bytes of b in big-endian byte order as a
+// uint16 value and returns it along with the rest of b.
+func DecodeUint16BE(b []byte) (uint16, []byte, error) {
+	h, rest, err := decodeN(b, 2)
+	if err != nil {
+		return 0, b, err
+	}
+	return binary.BigEndian.Uint16(h), rest, nil
+}
+
+// AppendUint16LE appends 2 bytes that represent the value v of uint16 in
+// little-endian byte order to dst and returns the extended buffer.
+func AppendUint16LE(dst []byte, v uint16) []byte {
+	return append(dst, byte(v), byte(v>>8))
+}
+
+// DecodeUint16LE decodes the first 2 bytes of b in little-endian byte order as
+// a uint16 value and returns it along with the rest of b.
+func DecodeUint16LE(b []byte) (uint16, []byte, error) {
+	h, rest, err := decodeN(b, 2)
+	if err != nil {
+		return 0, b, err
+	}
+	return binary.LittleEndian.Uint16(h), rest, nil
+}
+
+// AppendInt16BE appends 2 bytes that represent the value v of int16 in
+// big-endian byte order to dst and returns the extended buffer.
+func AppendInt16BE(dst []byte, v int16) []byte {
+	u := uint16(v)
+	return append(dst, byte(u>>8), byte(u))
+}
+
+// DecodeInt16BE decodes the first 2 bytes of b in big-endian byte order as an
+// int16 value and returns it along with the rest of b.
+func DecodeInt16BE(b []byte) (int16, []byte, error) {
+	h, rest, err := decodeN(b, 2)
+	if err != nil {
+		return 0, b, err
+	}
+	return int16(binary.BigEndian.Uint16(h)), rest, nil
+}
+
+// AppendInt16LE appends 2 bytes that represent the value v of int16 in
+// little-endian byte order to dst and returns the extended buffer.
+func AppendInt16LE(dst []byte, v int16) []byte {
+	u := uint16(v)
+	return append(dst, byte(u), byte(u>>8))
+}
+
+// DecodeInt16LE decodes the first 2 bytes of b in little-endian byte order as
+// an int16 value and returns it along with the rest of b.
+func DecodeInt16LE(b []byte) (int16, []byte, error) {
+	h, rest, err := decodeN(b, 2)
+	if err != nil {
+		return 0, b, err
+	}
+	return int16(binary.LittleEndian.Uint16(h)), rest, nil
+}
+
+// AppendUint32BE appends 4 bytes that represent the value v of uint32 in
+// big-endian byte order to dst and returns the extended buffer.
+func AppendUint32BE(dst []byte, v uint32) []byte {
+	return append(dst, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+// DecodeUint32BE decodes the first 4 bytes of b in big-endian byte order as a
+// uint32 value and returns it along with the rest of b.
+func DecodeUint32BE(b []byte) (uint32, []byte, error) {
+	h, rest, err := decodeN(b, 4)
+	if err != nil {
+		return 0, b, err
+	}
+	return binary.BigEndian.Uint32(h), rest, nil
+}
+
+// AppendUint32LE appends 4 bytes that represent the value v of uint32 in
+// little-endian byte order to dst and returns the extended buffer.
+func AppendUint32LE(dst []byte, v uint32) []byte {
+	return append(dst, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+// DecodeUint32LE decodes the first 4 bytes of b in little-endian byte order as
+// a uint32 value and returns it along with the rest of b.
+func DecodeUint32LE(b []byte) (uint32, []byte, error) {
+	h, rest, err := decodeN(b, 4)
+	if err != nil {
+		return 0, b, err
+	}
+	return binary.LittleEndian.Uint32(h), rest, nil
+}
+
+// AppendInt32BE appends 4 bytes that represent the value v of int32 in
+// big-endian byte order to dst and returns the extended buffer.
+func AppendInt32BE(dst []byte, v int32) []byte {
+	u := uint32(v)
+	return append(dst, byte(u>>24), byte(u>>16), byte(u>>8), byte(u))
+}
+
+// DecodeInt32BE decodes the first 4 bytes of b in big-endian byte order as an
+// int32 value and returns it along with the rest of b.
+func DecodeInt32BE(b []byte) (int32, []byte, error) {
+	h, rest, err := decodeN(b, 4)
+	if err != nil {
+		return 0, b, err
+	}
+	return int32(binary.BigEndian.Uint32(h)), rest, nil
+}
+
+// AppendInt32LE appends 4 bytes that represent the value v of int32 in
+// little-endian byte order to dst and returns the extended buffer.
+func AppendInt32LE(dst []byte, v int32) []byte {
+	u := uint32(v)
+	return append(dst, byte(u), byte(u>>8), byte(u>>16), byte(u>>24))
+}
+
+// DecodeInt32LE decodes the first 4 bytes of b in little-endian byte order as
+// an int32 value and returns it along with the rest of b.
+func DecodeInt32LE(b []byte) (int32, []byte, error) {
+	h, rest, err := decodeN(b, 4)
+	if err != nil {
+		return 0, b, err
+	}
+	return int32(binary.LittleEndian.Uint32(h)), rest, nil
+}
+
+// AppendUint64BE appends 8 bytes that represent the value v of uint64 in
+// big-endian byte order to dst and returns the extended buffer.
+func AppendUint64BE(dst []byte, v uint64) []byte {
+	return append(dst, byte(v>>56), byte(v>>48), byte(v>>40), byte(v>>32), byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+// DecodeUint64BE decodes the first 8 bytes of b in big-endian byte order as a
+// uint64 value and returns it along with the rest of b.
+func DecodeUint64BE(b []byte) (uint64, []byte, error) {
+	h, rest, err := decodeN(b, 8)
+	if err != nil {
+		return 0, b, err
+	}
+	return binary.BigEndian.Uint64(h), rest, nil
+}
+
+// AppendUint64LE appends 8 bytes that represent the value v of uint64 in
+// little-endian byte order to dst and returns the extended buffer.
+func AppendUint64LE(dst []byte, v uint64) []byte {
+	return append(dst, byte(v), byte(v>>8), byte(v>>16), byte(v>>24), byte(v>>32), byte(v>>40), byte(v>>48), byte(v>>56))
+}
+
+// DecodeUint64LE decodes the first 8 bytes of b in little-endian byte order as
+// a uint64 value and returns it along with the rest of b.
+func DecodeUint64LE(b []byte) (uint64, []byte, error) {
+	h, rest, err := decodeN(b, 8)
+	if err != nil {
+		return 0, b, err
+	}
+	return binary.LittleEndian.Uint64(h), rest, nil
+}
+
+// AppendInt64BE appends 8 bytes that represent the value v of int64 in
+// big-endian byte order to dst and returns the extended buffer.
+func AppendInt64BE(dst []byte, v int64) []byte {
+	u := uint64(v)
+	return append(dst, byte(u>>56), byte(u>>48), byte(u>>40), byte(u>>32), byte(u>>24), byte(u>>16), byte(u>>8), byte(u))
+}
+
+// DecodeInt64BE decodes the first 8 bytes of b in big-endian byte order as an
+// int64 value and returns it along with the rest of b.
+func DecodeInt64BE(b []byte) (int64, []byte, error) {
+	h, rest, err := decodeN(b, 8)
+	if err != nil {
+		return 0, b, err
+	}
+	return int64(binary.BigEndian.Uint64(h)), rest, nil
+}
+
+// AppendInt64LE appends 8 bytes that represent the value v of int64 in
+// little-endian byte order to dst and returns the extended buffer.
+func AppendInt64LE(dst []byte, v int64) []byte {
+	u := uint64(v)
+	return append(dst, byte(u), byte(u>>8), byte(u>>16), byte(u>>24), byte(u>>32), byte(u>>40), byte(u>>48), byte(u>>56))
+}
+
+// DecodeInt64LE decodes the first 8 bytes of b in little-endian byte order as
+// an int64 value and returns it along with the rest of b.
+func DecodeInt64LE(b []byte) (int64, []byte, error) {
+	h, rest, err := decodeN(b, 8)
+	if err != nil {
+		return 0, b, err
+	}
+	return int64(binary.LittleEndian.Uint64(h)), rest, nil
+}
+
+// AppendUint24BE appends 3 bytes that represent the value v in big-endian byte
+// order to dst and returns the extended buffer. ErrValueOutOfRange is returned
+// if v does not fit in 24 bits.
+func AppendUint24BE(dst []byte, v uint32) ([]byte, error) {
+	if v>>24 != 0 {
+		return dst, fmt.Errorf("%w: %d does not fit in 24 bits", ErrValueOutOfRange, v)
+	}
+	var a [3]byte
+	return append(dst, putUintBE(a[:], uint64(v))...), nil
+}
+
+// DecodeUint24BE decodes the first 3 bytes of b in big-endian byte order as a
+// uint32 value and returns it along with the rest of b.
+func DecodeUint24BE(b []byte) (uint32, []byte, error) {
+	h, rest, err := decodeN(b, 3)
+	if err != nil {
+		return 0, b, err
+	}
+	return uint32(uintBE(h)), rest, nil
+}
+
+// AppendUint24LE appends 3 bytes that represent the value v in little-endian
+// byte order to dst and returns the extended buffer. ErrValueOutOfRange is
+// returned if v does not fit in 24 bits.
+func AppendUint24LE(dst []byte, v uint32) ([]byte, error) {
+	if v>>24 != 0 {
+		return dst, fmt.Errorf("%w: %d does not fit in 24 bits", ErrValueOutOfRange, v)
+	}
+	var a [3]byte
+	return append(dst, putUintLE(a[:], uint64(v))...), nil
+}
+
+// DecodeUint24LE decodes the first 3 bytes of b in little-endian byte order as
+// a uint32 value and returns it along with the rest of b.
+func DecodeUint24LE(b []byte) (uint32, []byte, error) {
+	h, rest, err := decodeN(b, 3)
+	if err != nil {
+		return 0, b, err
+	}
+	return uint32(uintLE(h)), rest, nil
+}
+
+// AppendInt24BE appends 3 bytes that represent the value v in big-endian byte
+// order to dst and returns the extended buffer. ErrValueOutOfRange is returned
+// if v does not fit in 24 bits.
+func AppendInt24BE(dst []byte, v int32) ([]byte, error) {
+	if v < -1<<23 || 1<<23-1 < v {
+		return dst, fmt.Errorf("%w: %d does not fit in 24 bits", ErrValueOutOfRange, v)
+	}
+	var a [3]byte
+	return append(dst, putUintBE(a[:], uint64(v))...), nil
+}
+
+// DecodeInt24BE decodes the first 3 bytes of b in big-endian byte order as an
+// int32 value and returns it along with the rest of b. The value is
+// sign-extended.
+func DecodeInt24BE(b []byte) (int32, []byte, error) {
+	h, rest, err := decodeN(b, 3)
+	if err != nil {
+		return 0, b, err
+	}
+	return int32(signExtend(uintBE(h), 24)), rest, nil
+}
+
+// AppendInt24LE appends 3 bytes that represent the value v in little-endian
+// byte order to dst and returns the extended buffer. ErrValueOutOfRange is
+// returned if v does not fit in 24 bits.
+func AppendInt24LE(dst []byte, v int32) ([]byte, error) {
+	if v < -1<<23 || 1<<23-1 < v {
+		return dst, fmt.Errorf("%w: %d does not fit in 24 bits", ErrValueOutOfRange, v)
+	}
+	var a [3]byte
+	return append(dst, putUintLE(a[:], uint64(v))...), nil
+}
+
+// DecodeInt24LE decodes the first 3 bytes of b in little-endian byte order as
+// an int32 value and returns it along with the rest of b. The value is
+// sign-extended.
+func DecodeInt24LE(b []byte) (int32, []byte, error) {
+	h, rest, err := decodeN(b, 3)
+	if err != nil {
+		return 0, b, err
+	}
+	return int32(signExtend(uintLE(h), 24)), rest, nil
+}
+
+// AppendUint40BE appends 5 bytes that represent the value v in big-endian byte
+// order to dst and returns the extended buffer. ErrValueOutOfRange is returned
+// if v does not fit in 40 bits.
+func AppendUint40BE(dst []byte, v uint64) ([]byte, error) {
+	if v>>40 != 0 {
+		return dst, fmt.Errorf("%w: %d does not fit in 40 bits", ErrValueOutOfRange, v)
+	}
+	var a [5]byte
+	return append(dst, putUintBE(a[:], v)...), nil
+}
+
+// DecodeUint40BE decodes the first 5 bytes of b in big-endian byte order as a
+// uint64 value and returns it along with the rest of b.
+func DecodeUint40BE(b []byte) (uint64, []byte, error) {
+	h, rest, err := decodeN(b, 5)
+	if err != nil {
+		return 0, b, err
+	}
+	return uintBE(h), rest, nil
+}
+
+// AppendUint40LE appends 5 bytes that represent the value v in little-endian
+// byte order to dst and returns the extended buffer. ErrValueOutOfRange is
+// returned if v does not fit in 40 bits.
+func AppendUint40LE(dst []byte, v uint64) ([]byte, error) {
+	if v>>40 != 0 {
+		return dst, fmt.Errorf("%w: %d does not fit in 40 bits", ErrValueOutOfRange, v)
+	}
+	var a [5]byte
+	return append(dst, putUintLE(a[:], v)...), nil
+}
+
+// DecodeUint40LE decodes the first 5 bytes of b in little-endian byte order as
+// a uint64 value and returns it along with the rest of b.
+func DecodeUint40LE(b []byte) (uint64, []byte, error) {
+	h, rest, err := decodeN(b, 5)
+	if err != nil {
+		return 0, b, err
+	}
+	return uintLE(h), rest, nil
+}
+
+// AppendInt40BE appends 5 bytes that represent the value v in big-endian byte
+// order to dst and returns the extended buffer. ErrValueOutOfRange is returned
+// if v does not fit in 40 bits.
+func AppendInt40BE(dst []byte, v int64) ([]byte, error) {
+	if v < -1<<39 || 1<<39-1 < v {
+		return dst, fmt.Errorf("%w: %d does not fit in 40 bits", ErrValueOutOfRange, v)
+	}
+	var a [5]byte
+	return append(dst, putUintBE(a[:], uint64(v))...), nil
+}
+
+// DecodeInt40BE decodes the first 5 bytes of b in big-endian byte order as an
+// int64 value and returns it along with the rest of b. The value is
+// sign-extended.
+func DecodeInt40BE(b []byte) (int64, []byte, error) {
+	h, rest, err := decodeN(b, 5)
+	if err != nil {
+		return 0, b, err
+	}
+	return signExtend(uintBE(h), 40), rest, nil
+}
+
+// AppendInt40LE appends 5 bytes that represent the value v in little-endian
+// byte order to dst and returns the extended buffer. ErrValueOutOfRange is
+// returned if v does not fit in 40 bits.
+func AppendInt40LE(dst []byte, v int64) ([]byte, error) {
+	if v < -1<<39 || 1<<39-1 < v {
+		return dst, fmt.Errorf("%w: %d does not fit in 40 bits", ErrValueOutOfRange, v)
+	}
+	var a [5]byte
+	return append(dst, putUintLE(a[:], uint64(v))...), nil
+}
+
+// DecodeInt40LE decodes the first 5 bytes of b in little-endian byte order as
+// an int64 value and returns it along with the rest of b. The value is
+// sign-extended.
+func DecodeInt40LE(b []byte) (int64, []byte, error) {
+	h, rest, err := decodeN(b, 5)
+	if err != nil {
+		return 0, b, err
+	}
+	return signExtend(uintLE(h), 40), rest, nil
+}
+
+// AppendUint48BE appends 6 bytes that represent the value v in big-endian byte
+// order to dst and returns the extended buffer. ErrValueOutOfRange is returned
+// if v does not fit in 48 bits.
+func AppendUint48BE(dst []byte, v uint64) ([]byte, error) {
+	if v>>48 != 0 {
+		return dst, fmt.Errorf("%w: %d does not fit in 48 bits", ErrValueOutOfRange, v)
+	}
+	var a [6]byte
+	return append(dst, putUintBE(a[:], v)...), nil
+}
+
+// DecodeUint48BE decodes the first 6 bytes of b in big-endian byte order as a
+// uint64 value and returns it along with the rest of b.
+func DecodeUint48BE(b []byte) (uint64, []byte, error) {
+	h, rest, err := decodeN(b, 6)
+	if err != nil {
+		return 0, b, err
+	}
+	return uintBE(h), rest, nil
+}
+
+// AppendUint48LE appends 6 bytes that represent the value v in little-endian
+// byte order to dst and returns the extended buffer. ErrValueOutOfRange is
+// returned if v does not fit in 48 bits.
+func AppendUint48LE(dst []byte, v uint64) ([]byte, error) {
+	if v>>48 != 0 {
+		return dst, fmt.Errorf("%w: %d does not fit in 48 bits", ErrValueOutOfRange, v)
+	}
+	var a [6]byte
+	return append(dst, putUintLE(a[:], v)...), nil
+}
+
+// DecodeUint48LE decodes the first 6 bytes of b in little-endian byte order as
+// a uint64 value and returns it along with the rest of b.
+func DecodeUint48LE(b []byte) (uint64, []byte, error) {
+	h, rest, err := decodeN(b, 6)
+	if err != nil {
+		return 0, b, err
+	}
+	return uintLE(h), rest, nil
+}
+
+// AppendInt48BE appends 6 bytes that represent the value v in big-endian byte
+// order to dst and returns the extended buffer. ErrValueOutOfRange is returned
+// if v does not fit in 48 bits.
+func AppendInt48BE(dst []byte, v int64) ([]byte, error) {
+	if v < -1<<47 || 1<<47-1 < v {
+		return dst, fmt.Errorf("%w: %d does not fit in 48 bits", ErrValueOutOfRange, v)
+	}
+	var a [6]byte
+	return append(dst, putUintBE(a[:], uint64(v))...), nil
+}
+
+// DecodeInt48BE decodes the first 6 bytes of b in big-endian byte order as an
+// int64 value and returns it along with the rest of b. The value is
+// sign-extended.
+func DecodeInt48BE(b []byte) (int64, []byte, error) {
+	h, rest, err := decodeN(b, 6)
+	if err != nil {
+		return 0, b, err
+	}
+	return signExtend(uintBE(h), 48), rest, nil
+}
+
+// AppendInt48LE appends 6 bytes that represent the value v in little-endian
+// byte order to dst and returns the extended buffer. ErrValueOutOfRange is
+// returned if v does not fit in 48 bits.
+func AppendInt48LE(dst []byte, v int64) ([]byte, error) {
+	if v < -1<<47 || 1<<47-1 < v {
+		return dst, fmt.Errorf("%w: %d does not fit in 48 bits", ErrValueOutOfRange, v)
+	}
+	var a [6]byte
+	return append(dst, putUintLE(a[:], uint64(v))...), nil
+}
+
+// DecodeInt48LE decodes the first 6 bytes of b in little-endian byte order as
+// an int64 value and returns it along with the rest of b. The value is
+// sign-extended.
+func DecodeInt48LE(b []byte) (int64, []byte, error) {
+	h, rest, err := decodeN(b, 6)
+	if err != nil {
+		return 0, b, err
+	}
+	return signExtend(uintLE(h), 48), rest, nil
+}
+
+// AppendUint56BE appends 7 bytes that represent the value v in big-endian byte
+// order to dst and returns the extended buffer. ErrValueOutOfRange is returned
+// if v does not fit in 56 bits.
+func AppendUint56BE(dst []byte, v uint64) ([]byte, error) {
+	if v>>56 != 0 {
+		return dst, fmt.Errorf("%w: %d does not fit in 56 bits", ErrValueOutOfRange, v)
+	}
+	var a [7]byte
+	return append(dst, putUintBE(a[:], v)...), nil
+}
+
+// DecodeUint56BE decodes the first 7 bytes of b in big-endian byte order as a
+// uint64 value and returns it along with the rest of b.
+func DecodeUint56BE(b []byte) (uint64, []byte, error) {
+	h, rest, err := decodeN(b, 7)
+	if err != nil {
+		return 0, b, err
+	}
+	return uintBE(h), rest, nil
+}
+
+// AppendUint56LE appends 7 bytes that represent the value v in little-endian
+// byte order to dst and returns the extended buffer. ErrValueOutOfRange is
+// returned if v does not fit in 56 bits.
+func AppendUint56LE(dst []byte, v uint64) ([]byte, error) {
+	if v>>56 != 0 {
+		return dst, fmt.Errorf("%w: %d does not fit in 56 bits", ErrValueOutOfRange, v)
+	}
+	var a [7]byte
+	return append(dst, putUintLE(a[:], v)...), nil
+}
+
+// DecodeUint56LE decodes the first 7 bytes of b in little-endian byte order as
+// a uint64 value and returns it along with the rest of b.
+func DecodeUint56LE(b []byte) (uint64, []byte, error) {
+	h, rest, err := decodeN(b, 7)
+	if err != nil {
+		return 0, b, err
+	}
+	return uintLE(h), rest, nil
+}
+
+// AppendInt56BE appends 7 bytes that represent the value v in big-endian byte
+// order to dst and returns the extended buffer. ErrValueOutOfRange is returned
+// if v does not fit in 56 bits.
+func AppendInt56BE(dst []byte, v int64) ([]byte, error) {
+	if v < -1<<55 || 1<<55-1 < v {
+		return dst, fmt.Errorf("%w: %d does not fit in 56 bits", ErrValueOutOfRange, v)
+	}
+	var a [7]byte
+	return append(dst, putUintBE(a[:], uint64(v))...), nil
+}
+
+// DecodeInt56BE decodes the first 7 bytes of b in big-endian byte order as an
+// int64 value and returns it along with the rest of b. The value is
+// sign-extended.
+func DecodeInt56BE(b []byte) (int64, []byte, error) {
+	h, rest, err := decodeN(b, 7)
+	if err != nil {
+		return 0, b, err
+	}
+	return signExtend(uintBE(h), 56), rest, nil
+}
+
+// AppendInt56LE appends 7 bytes that represent the value v in little-endian
+// byte order to dst and returns the extended buffer. ErrValueOutOfRange is
+// returned if v does not fit in 56 bits.
+func AppendInt56LE(dst []byte, v int64) ([]byte, error) {
+	if v < -1<<55 || 1<<55-1 < v {
+		return dst, fmt.Errorf("%w: %d does not fit in 56 bits", ErrValueOutOfRange, v)
+	}
+	var a [7]byte
+	return append(dst, putUintLE(a[:], uint64(v))...), nil
+}
+
+// DecodeInt56LE decodes the first 7 bytes of b in little-endian byte order as
+// an int64 value and returns it along with the rest of b. The value is
+// sign-extended.
+func DecodeInt56LE(b []byte) (int64, []byte, error) {
+	h, rest, err := decodeN(b, 7)
+	if err != nil {
+		return 0, b, err
+	}
+	return signExtend(uintLE(h), 56), rest, nil
+}
+
+// AppendUint128BE appends 16 bytes that represent the value v of Uint128 in
+// big-endian byte order to dst and returns the extended buffer.
+func AppendUint128BE(dst []byte, v Uint128) []byte {
+	return AppendUint64BE(AppendUint64BE(dst, v.Hi), v.Lo)
+}
+
+// DecodeUint128BE decodes the first 16 bytes of b in big-endian byte order as a
+// Uint128 value and returns it along with the rest of b.
+func DecodeUint128BE(b []byte) (Uint128, []byte, error) {
+	h, rest, err := decodeN(b, 16)
+	if err != nil {
+		return Uint128{}, b, err
+	}
+	return Uint128{
+		Hi: binary.BigEndian.Uint64(h[:8]),
+		Lo: binary.BigEndian.Uint64(h[8:]),
+	}, rest, nil
+}
+
+// AppendUint128LE appends 16 bytes that represent the value v of Uint128 in
+// little-endian byte order to dst and returns the extended buffer.
+func AppendUint128LE(dst []byte, v Uint128) []byte {
+	return AppendUint64LE(AppendUint64LE(dst, v.Lo), v.Hi)
+}
+
+// DecodeUint128LE decodes the first 16 bytes of b in little-endian byte order
+// as a Uint128 value and returns it along with the rest of b.
+func DecodeUint128LE(b []byte) (Uint128, []byte, error) {
+	h, rest, err := decodeN(b, 16)
+	if err != nil {
+		return Uint128{}, b, err
+	}
+	return Uint128{
+		Lo: binary.LittleEndian.Uint64(h[:8]),
+		Hi: binary.LittleEndian.Uint64(h[8:]),
+	}, rest, nil
+}
+
+// AppendQUICVarint appends the value v of uint64 to dst as a variable-length
+// integer defined in RFC 9000 section 16, using the minimum number of bytes,
+// and returns the extended buffer. ErrValueOutOfRange is returned if v exceeds
+// MaxQUICVarint.
+func AppendQUICVarint(dst []byte, v uint64) ([]byte, error) {
+	return AppendQUICVarintN(dst, v, quicVarintLen(v))
+}
+
+// AppendQUICVarintN is identical to AppendQUICVarint except that it always
+// appends the value using the encoding of n bytes. The n must be one of 1, 2, 4
+// or 8, otherwise ErrInvalidSize is returned. ErrValueOutOfRange is returned if
+// v does not fit in n bytes.
+func AppendQUICVarintN(dst []byte, v uint64, n int) ([]byte, error) {
+	var prefix byte
+	switch n {
+	case 1:
+		prefix = 0x00
+	case 2:
+		prefix = 0x40
+	case 4:
+		prefix = 0x80
+	case 8:
+		prefix = 0xc0
+	default:
+		return dst, fmt.Errorf("%w: QUIC varint length %d", ErrInvalidSize, n)
+	}
+	if v>>(8*n-2) != 0 {
+		return dst, fmt.Errorf("%w: %d does not fit in %d-byte QUIC varint", ErrValueOutOfRange, v, n)
+	}
+	var a [8]byte
+	b := putUintBE(a[:n], v)
+	b[0] |= prefix
+	return append(dst, b...), nil
+}
+
+// DecodeQUICVarint decodes a variable-length integer defined in RFC 9000
+// section 16 at the beginning of b and returns it along with the rest of b.
+// Non-minimal encodings are accepted.
+func DecodeQUICVarint(b []byte) (uint64, []byte, error) {
+	n := 1
+	if len(b) != 0 {
+		n = 1 << (b[0] >> 6)
+	}
+	h, rest, err := decodeN(b, n)
+	if err != nil {
+		return 0, b, err
+	}
+	return uintBE(h) &^ (3 << (8*len(h) - 2)), rest, nil
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tunabay/go-typeio"
+)
+
+// noErr adapts an Append function that never fails.
+func noErr[T any](f func([]byte, T) []byte) func([]byte, T) ([]byte, error) {
+	return func(dst []byte, v T) ([]byte, error) { return f(dst, v), nil }
+}
+
+// testAppendDecode tests that app appends the bytes b represented in hex for
+// v, and dec decodes them back to v.
+func testAppendDecode[T any](
+	t *testing.T,
+	name string,
+	v T,
+	b string,
+	app func([]byte, T) ([]byte, error),
+	dec func([]byte) (T, []byte, error),
+) {
+	t.Helper()
+	want, err := hex.DecodeString(b)
+	if err != nil {
+		t.Fatalf("%s: invalid test data: %q: %s", name, b, err)
+	}
+	prefix, suffix := []byte{0xaa}, []byte{0xbb, 0xcc}
+
+	got, err := app(append([]byte{}, prefix...), v)
+	if err != nil {
+		t.Errorf("%s: %v: unexpected error: %s", name, v, err)
+	} else if !bytes.Equal(got, append(append([]byte{}, prefix...), want...)) {
+		t.Errorf("%s: %v: unexpected append: got %x, want %x%s", name, v, got, prefix, b)
+	}
+
+	dv, rest, err := dec(append(append([]byte{}, want...), suffix...))
+	switch {
+	case err != nil:
+		t.Errorf("%s: %s: unexpected error: %s", name, b, err)
+	case !equalValue(dv, v):
+		t.Errorf("%s: %s: unexpected decode: got %v, want %v", name, b, dv, v)
+	case !bytes.Equal(rest, suffix):
+		t.Errorf("%s: %s: unexpected rest: got %x, want %x", name, b, rest, suffix)
+	}
+
+	if _, rest, err := dec(nil); !errors.Is(err, io.EOF) || len(rest) != 0 {
+		t.Errorf("%s: unexpected error: got %v, want %v", name, err, io.EOF)
+	}
+	short := want[:len(want)-1]
+	if 0 < len(short) {
+		if _, rest, err := dec(short); !errors.Is(err, io.ErrUnexpectedEOF) || !bytes.Equal(rest, short) {
+			t.Errorf("%s: unexpected error: got %v, %x, want %v", name, err, rest, io.ErrUnexpectedEOF)
+		}
+	}
+}
+
+// equalValue returns whether x and y are equal, comparing time.Time values with
+// the Equal method.
+func equalValue(x, y interface{}) bool {
+	if tx, ok := x.(time.Time); ok {
+		ty, ok := y.(time.Time)
+		return ok && tx.Equal(ty)
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+func TestAppendDecodeInteger(t *testing.T) {
+	testAppendDecode(t, "Uint8", 0xf0, "f0", noErr(typeio.AppendUint8), typeio.DecodeUint8)
+	testAppendDecode(t, "Int8", -2, "fe", noErr(typeio.AppendInt8), typeio.DecodeInt8)
+	testAppendDecode(t, "Uint16BE", 0x1234, "1234", noErr(typeio.AppendUint16BE), typeio.DecodeUint16BE)
+	testAppendDecode(t, "Uint16LE", 0x1234, "3412", noErr(typeio.AppendUint16LE), typeio.DecodeUint16LE)
+	testAppendDecode(t, "Int16BE", -2, "fffe", noErr(typeio.AppendInt16BE), typeio.DecodeInt16BE)
+	testAppendDecode(t, "Int16LE", -2, "feff", noErr(typeio.AppendInt16LE), typeio.DecodeInt16LE)
+	testAppendDecode(t, "Uint32BE", 0x12345678, "12345678", noErr(typeio.AppendUint32BE), typeio.DecodeUint32BE)
+	testAppendDecode(t, "Uint32LE", 0x12345678, "78563412", noErr(typeio.AppendUint32LE), typeio.DecodeUint32LE)
+	testAppendDecode(t, "Int32BE", -2, "fffffffe", noErr(typeio.AppendInt32BE), typeio.DecodeInt32BE)
+	testAppendDecode(t, "Int32LE", -2, "feffffff", noErr(typeio.AppendInt32LE), typeio.DecodeInt32LE)
+	testAppendDecode(t, "Uint64BE", 0x0123456789abcdef, "0123456789abcdef", noErr(typeio.AppendUint64BE), typeio.DecodeUint64BE)
+	testAppendDecode(t, "Uint64LE", 0x0123456789abcdef, "efcdab8967452301", noErr(typeio.AppendUint64LE), typeio.DecodeUint64LE)
+	testAppendDecode(t, "Int64BE", -2, "fffffffffffffffe", noErr(typeio.AppendInt64BE), typeio.DecodeInt64BE)
+	testAppendDecode(t, "Int64LE", -2, "feffffffffffffff", noErr(typeio.AppendInt64LE), typeio.DecodeInt64LE)
+
+	testAppendDecode(t, "Uint24BE", 0x123456, "123456", typeio.AppendUint24BE, typeio.DecodeUint24BE)
+	testAppendDecode(t, "Uint24LE", 0x123456, "563412", typeio.AppendUint24LE, typeio.DecodeUint24LE)
+	testAppendDecode(t, "Int24BE", -2, "fffffe", typeio.AppendInt24BE, typeio.DecodeInt24BE)
+	testAppendDecode(t, "Int24LE", -1<<23, "000080", typeio.AppendInt24LE, typeio.DecodeInt24LE)
+	testAppendDecode(t, "Uint40BE", 0x123456789a, "123456789a", typeio.AppendUint40BE, typeio.DecodeUint40BE)
+	testAppendDecode(t, "Uint40LE", 0x123456789a, "9a78563412", typeio.AppendUint40LE, typeio.DecodeUint40LE)
+	testAppendDecode(t, "Int40BE", -2, "fffffffffe", typeio.AppendInt40BE, typeio.DecodeInt40BE)
+	testAppendDecode(t, "Int40LE", 1<<39-1, "ffffffff7f", typeio.AppendInt40LE, typeio.DecodeInt40LE)
+	testAppendDecode(t, "Uint48BE", 0x123456789abc, "123456789abc", typeio.AppendUint48BE, typeio.DecodeUint48BE)
+	testAppendDecode(t, "Uint48LE", 0x123456789abc, "bc9a78563412", typeio.AppendUint48LE, typeio.DecodeUint48LE)
+	testAppendDecode(t, "Int48BE", -1<<47, "800000000000", typeio.AppendInt48BE, typeio.DecodeInt48BE)
+	testAppendDecode(t, "Int48LE", -2, "feffffffffff", typeio.AppendInt48LE, typeio.DecodeInt48LE)
+	testAppendDecode(t, "Uint56BE", 0x123456789abcde, "123456789abcde", typeio.AppendUint56BE, typeio.DecodeUint56BE)
+	testAppendDecode(t, "Uint56LE", 0x123456789abcde, "debc9a78563412", typeio.AppendUint56LE, typeio.DecodeUint56LE)
+	testAppendDecode(t, "Int56BE", -2, "fffffffffffffe", typeio.AppendInt56BE, typeio.DecodeInt56BE)
+	testAppendDecode(t, "Int56LE", 1<<55-1, "ffffffffffff7f", typeio.AppendInt56LE, typeio.DecodeInt56LE)
+
+	v128 := typeio.Uint128{Hi: 0x0123456789abcdef, Lo: 0xfedcba9876543210}
+	testAppendDecode(t, "Uint128BE", v128, "0123456789abcdeffedcba9876543210", noErr(typeio.AppendUint128BE), typeio.DecodeUint128BE)
+	testAppendDecode(t, "Uint128LE", v128, "1032547698badcfeefcdab8967452301", noErr(typeio.AppendUint128LE), typeio.DecodeUint128LE)
+
+	testAppendDecode(t, "QUICVarint", 37, "25", typeio.AppendQUICVarint, typeio.DecodeQUICVarint)
+	testAppendDecode(t, "QUICVarint", 15293, "7bbd", typeio.AppendQUICVarint, typeio.DecodeQUICVarint)
+	testAppendDecode(t, "QUICVarint", 494878333, "9d7f3e7d", typeio.AppendQUICVarint, typeio.DecodeQUICVarint)
+	testAppendDecode(t, "QUICVarint", 151288809941952652, "c2197c5eff14e88c", typeio.AppendQUICVarint, typeio.DecodeQUICVarint)
+	testAppendDecode(t, "Append/Decode[int16]", -2,
+		"feff",
+		noErr(func(dst []byte, v int16) []byte { return typeio.Append(dst, v, binary.LittleEndian) }),
+		func(b []byte) (int16, []byte, error) { return typeio.Decode[int16](b, binary.LittleEndian) },
+	)
+	testAppendDecode(t, "Append/Decode[float64]", 1,
+		"3ff0000000000000",
+		noErr(func(dst []byte, v float64) []byte { return typeio.Append(dst, v, nil) }),
+		func(b []byte) (float64, []byte, error) { return typeio.Decode[float64](b, nil) },
+	)
+}
+
+func TestAppendInteger_error(t *testing.T) {
+	dst := []byte{1, 2}
+	tcs := []struct {
+		name string
+		f    func([]byte) ([]byte, error)
+		e    error
+	}{
+		{"Uint24BE", func(b []byte) ([]byte, error) { return typeio.AppendUint24BE(b, 1<<24) }, typeio.ErrValueOutOfRange},
+		{"Int24LE", func(b []byte) ([]byte, error) { return typeio.AppendInt24LE(b, 1<<23) }, typeio.ErrValueOutOfRange},
+		{"Uint40LE", func(b []byte) ([]byte, error) { return typeio.AppendUint40LE(b, 1<<40) }, typeio.ErrValueOutOfRange},
+		{"Int48BE", func(b []byte) ([]byte, error) { return typeio.AppendInt48BE(b, -1<<47-1) }, typeio.ErrValueOutOfRange},
+		{"Uint56BE", func(b []byte) ([]byte, error) { return typeio.AppendUint56BE(b, 1<<56) }, typeio.ErrValueOutOfRange},
+		{"QUICVarint", func(b []byte) ([]byte, error) { return typeio.AppendQUICVarint(b, typeio.MaxQUICVarint+1) }, typeio.ErrValueOutOfRange},
+		{"QUICVarintN", func(b []byte) ([]byte, error) { return typeio.AppendQUICVarintN(b, 64, 1) }, typeio.ErrValueOutOfRange},
+		{"QUICVarintN", func(b []byte) ([]byte, error) { return typeio.AppendQUICVarintN(b, 1, 3) }, typeio.ErrInvalidSize},
+	}
+	for _, tc := range tcs {
+		got, err := tc.f(dst)
+		if !errors.Is(err, tc.e) {
+			t.Errorf("%s: unexpected error: got %v, want %v", tc.name, err, tc.e)
+		}
+		if !bytes.Equal(got, dst) {
+			t.Errorf("%s: dst modified: %x", tc.name, got)
+		}
+	}
+}
+
+func TestAppendDecode_allocs(t *testing.T) {
+	buf := make([]byte, 0, 64)
+	b := typeio.AppendUint64LE(buf, 1)
+	allocs := testing.AllocsPerRun(100, func() {
+		b = typeio.AppendUint32BE(buf, 0x12345678)
+		b, _ = typeio.AppendInt48LE(b, -2)
+		b = typeio.AppendFloat64BE(b, 1.5)
+		b = typeio.Append(b, uint16(1), binary.LittleEndian)
+		b, _ = typeio.AppendQUICVarint(b, 15293)
+		_, rest, _ := typeio.DecodeUint32BE(b)
+		_, rest, _ = typeio.DecodeInt48LE(rest)
+		_, rest, _ = typeio.DecodeFloat64BE(rest)
+		_, rest, _ = typeio.Decode[uint16](rest, binary.LittleEndian)
+		_, _, _ = typeio.DecodeQUICVarint(rest)
+	})
+	if allocs != 0 {
+		t.Errorf("unexpected allocations: %v", allocs)
+	}
+}
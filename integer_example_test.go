@@ -509,3 +509,98 @@ func ExampleWriteInt64LE() {
 	// Output:
 	// 0807060504030201feffffffffffffff
 }
+
+func ExampleReadQUICVarint() {
+	b, _ := hex.DecodeString("257bbd9d7f3e7dc2197c5eff14e88c")
+	r := bytes.NewReader(b)
+
+	for {
+		v, n, err := typeio.ReadQUICVarint(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			panic(err)
+		}
+		fmt.Println(n, v)
+	}
+
+	// Output:
+	// 1 37
+	// 2 15293
+	// 4 494878333
+	// 8 151288809941952652
+}
+
+func ExampleWriteQUICVarint() {
+	w := new(bytes.Buffer)
+
+	data := []uint64{37, 15293, 494878333, 151288809941952652}
+	for _, v := range data {
+		if _, err := typeio.WriteQUICVarint(w, v); err != nil {
+			panic(err)
+		}
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	// Output:
+	// 257bbd9d7f3e7dc2197c5eff14e88c
+}
+
+func ExampleWriteQUICVarintN() {
+	w := new(bytes.Buffer)
+
+	if _, err := typeio.WriteQUICVarintN(w, 37, 2); err != nil {
+		panic(err)
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	_, err := typeio.WriteQUICVarintN(w, 16384, 2)
+	fmt.Println(err)
+
+	// Output:
+	// 4025
+	// value out of range: 16384 does not fit in 2-byte QUIC varint
+}
+
+func ExampleReadUint24BE() {
+	// TLS handshake header: type and 24-bit length
+	b, _ := hex.DecodeString("010001fc")
+	r := bytes.NewReader(b)
+
+	typ, _ := typeio.ReadUint8(r)
+	n, err := typeio.ReadUint24BE(r)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(typ, n)
+
+	// Output:
+	// 1 508
+}
+
+func ExampleAppendUint16BE() {
+	b := typeio.AppendUint16BE(nil, 0x0102)
+	b = typeio.AppendUint32LE(b, 0x03040506)
+	b = typeio.AppendInt8(b, -1)
+
+	fmt.Printf("%x\n", b)
+
+	// Output:
+	// 010206050403ff
+}
+
+func ExampleDecodeUint16BE() {
+	b, _ := hex.DecodeString("010206050403ff")
+	u16, b, _ := typeio.DecodeUint16BE(b)
+	u32, b, _ := typeio.DecodeUint32LE(b)
+	i8, b, _ := typeio.DecodeInt8(b)
+	_, _, err := typeio.DecodeInt8(b)
+
+	fmt.Printf("%#x %#x %d\n", u16, u32, i8)
+	fmt.Println(err)
+
+	// Output:
+	// 0x102 0x3040506 -1
+	// EOF
+}
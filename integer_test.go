@@ -903,3 +903,357 @@ func TestWriteInt64LE(t *testing.T) {
 		}
 	}
 }
+
+func TestReadQUICVarint(t *testing.T) {
+	tcs := []struct {
+		b string
+		v uint64
+		n int
+		e error
+	}{
+		{"00", 0, 1, nil},
+		{"25", 37, 1, nil},
+		{"4025", 37, 2, nil},
+		{"7bbd", 15293, 2, nil},
+		{"9d7f3e7d", 494878333, 4, nil},
+		{"c2197c5eff14e88c", 151288809941952652, 8, nil},
+		{"ffffffffffffffff", typeio.MaxQUICVarint, 8, nil},
+		{"8000000000", 0, 4, nil},
+		{"", 0, 0, io.EOF},
+		{"40", 0, 1, io.ErrUnexpectedEOF},
+		{"9d7f3e", 0, 1, io.ErrUnexpectedEOF},
+		{"c2197c5eff14e8", 0, 1, io.ErrUnexpectedEOF},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		r := bytes.NewReader(b)
+		got, n, err := typeio.ReadQUICVarint(r)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%q: error expected: got %d", tc.b, got)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%q: unexpected type of error: got %q, want %q", tc.b, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%q: unexpected error: %s", tc.b, err)
+		case tc.e == nil && got != tc.v:
+			t.Errorf("%q: unexpected read: got %d, want %d", tc.b, got, tc.v)
+		case tc.e == nil && n != tc.n:
+			t.Errorf("%q: unexpected read len: got %d, want %d", tc.b, n, tc.n)
+		}
+	}
+}
+
+func TestWriteQUICVarint(t *testing.T) {
+	tcs := []struct {
+		v uint64
+		b string
+		e error
+	}{
+		{0, "00", nil},
+		{37, "25", nil},
+		{63, "3f", nil},
+		{64, "4040", nil},
+		{15293, "7bbd", nil},
+		{494878333, "9d7f3e7d", nil},
+		{151288809941952652, "c2197c5eff14e88c", nil},
+		{typeio.MaxQUICVarint, "ffffffffffffffff", nil},
+		{typeio.MaxQUICVarint + 1, "", typeio.ErrValueOutOfRange},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		n, err := typeio.WriteQUICVarint(w, tc.v)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%d: error expected.", tc.v)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%d: unexpected type of error: got %q, want %q", tc.v, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%d: unexpected error: %s", tc.v, err)
+		case tc.e == nil && n != len(tc.b)/2:
+			t.Errorf("%d: unexpected write len: got %d, want %d", tc.v, n, len(tc.b)/2)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%d: unexpected write: got %s, want %s", tc.v, got, tc.b)
+		}
+	}
+}
+
+func TestWriteQUICVarintN(t *testing.T) {
+	tcs := []struct {
+		v uint64
+		n int
+		b string
+		e error
+	}{
+		{37, 1, "25", nil},
+		{37, 2, "4025", nil},
+		{37, 4, "80000025", nil},
+		{37, 8, "c000000000000025", nil},
+		{15293, 4, "80003bbd", nil},
+		{64, 1, "", typeio.ErrValueOutOfRange},
+		{16384, 2, "", typeio.ErrValueOutOfRange},
+		{1 << 30, 4, "", typeio.ErrValueOutOfRange},
+		{1 << 62, 8, "", typeio.ErrValueOutOfRange},
+		{37, 0, "", typeio.ErrInvalidSize},
+		{37, 3, "", typeio.ErrInvalidSize},
+		{37, 16, "", typeio.ErrInvalidSize},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		n, err := typeio.WriteQUICVarintN(w, tc.v, tc.n)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%d, %d: error expected.", tc.v, tc.n)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%d, %d: unexpected type of error: got %q, want %q", tc.v, tc.n, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%d, %d: unexpected error: %s", tc.v, tc.n, err)
+		case tc.e == nil && n != tc.n:
+			t.Errorf("%d, %d: unexpected write len: got %d", tc.v, tc.n, n)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%d, %d: unexpected write: got %s, want %s", tc.v, tc.n, got, tc.b)
+		}
+	}
+}
+
+func TestReadUintN(t *testing.T) {
+	type fn = func(io.Reader) (uint64, error)
+	u24 := func(f func(io.Reader) (uint32, error)) fn {
+		return func(r io.Reader) (uint64, error) { v, err := f(r); return uint64(v), err }
+	}
+	tcs := []struct {
+		name   string
+		be, le fn
+		b      string
+		v      uint64
+	}{
+		{"24", u24(typeio.ReadUint24BE), u24(typeio.ReadUint24LE), "000000", 0},
+		{"24", u24(typeio.ReadUint24BE), u24(typeio.ReadUint24LE), "123456", 0x123456},
+		{"24", u24(typeio.ReadUint24BE), u24(typeio.ReadUint24LE), "ffffff", 0xffffff},
+		{"40", typeio.ReadUint40BE, typeio.ReadUint40LE, "123456789a", 0x123456789a},
+		{"40", typeio.ReadUint40BE, typeio.ReadUint40LE, "ffffffffff", 0xffffffffff},
+		{"48", typeio.ReadUint48BE, typeio.ReadUint48LE, "123456789abc", 0x123456789abc},
+		{"48", typeio.ReadUint48BE, typeio.ReadUint48LE, "ffffffffffff", 0xffffffffffff},
+		{"56", typeio.ReadUint56BE, typeio.ReadUint56LE, "123456789abcde", 0x123456789abcde},
+		{"56", typeio.ReadUint56BE, typeio.ReadUint56LE, "ffffffffffffff", 0xffffffffffffff},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		rev := make([]byte, len(b))
+		for i := range b {
+			rev[len(b)-1-i] = b[i]
+		}
+		if got, err := tc.be(bytes.NewReader(b)); err != nil || got != tc.v {
+			t.Errorf("%s BE: %q: unexpected read: got %x, %v, want %x", tc.name, tc.b, got, err, tc.v)
+		}
+		if got, err := tc.le(bytes.NewReader(rev)); err != nil || got != tc.v {
+			t.Errorf("%s LE: %x: unexpected read: got %x, %v, want %x", tc.name, rev, got, err, tc.v)
+		}
+		if _, err := tc.be(bytes.NewReader(nil)); !errors.Is(err, io.EOF) {
+			t.Errorf("%s BE: unexpected error: got %v, want %q", tc.name, err, io.EOF)
+		}
+		if _, err := tc.le(bytes.NewReader(b[:len(b)-1])); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("%s LE: unexpected error: got %v, want %q", tc.name, err, io.ErrUnexpectedEOF)
+		}
+	}
+}
+
+func TestReadIntN(t *testing.T) {
+	type fn = func(io.Reader) (int64, error)
+	i24 := func(f func(io.Reader) (int32, error)) fn {
+		return func(r io.Reader) (int64, error) { v, err := f(r); return int64(v), err }
+	}
+	tcs := []struct {
+		name   string
+		be, le fn
+		b      string
+		v      int64
+	}{
+		{"24", i24(typeio.ReadInt24BE), i24(typeio.ReadInt24LE), "000000", 0},
+		{"24", i24(typeio.ReadInt24BE), i24(typeio.ReadInt24LE), "7fffff", 1<<23 - 1},
+		{"24", i24(typeio.ReadInt24BE), i24(typeio.ReadInt24LE), "800000", -1 << 23},
+		{"24", i24(typeio.ReadInt24BE), i24(typeio.ReadInt24LE), "ffffff", -1},
+		{"40", typeio.ReadInt40BE, typeio.ReadInt40LE, "7fffffffff", 1<<39 - 1},
+		{"40", typeio.ReadInt40BE, typeio.ReadInt40LE, "8000000000", -1 << 39},
+		{"40", typeio.ReadInt40BE, typeio.ReadInt40LE, "fffffffffe", -2},
+		{"48", typeio.ReadInt48BE, typeio.ReadInt48LE, "7fffffffffff", 1<<47 - 1},
+		{"48", typeio.ReadInt48BE, typeio.ReadInt48LE, "800000000000", -1 << 47},
+		{"48", typeio.ReadInt48BE, typeio.ReadInt48LE, "123456789abc", 0x123456789abc},
+		{"56", typeio.ReadInt56BE, typeio.ReadInt56LE, "7fffffffffffff", 1<<55 - 1},
+		{"56", typeio.ReadInt56BE, typeio.ReadInt56LE, "80000000000000", -1 << 55},
+		{"56", typeio.ReadInt56BE, typeio.ReadInt56LE, "ffffffffffffff", -1},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		rev := make([]byte, len(b))
+		for i := range b {
+			rev[len(b)-1-i] = b[i]
+		}
+		if got, err := tc.be(bytes.NewReader(b)); err != nil || got != tc.v {
+			t.Errorf("%s BE: %q: unexpected read: got %d, %v, want %d", tc.name, tc.b, got, err, tc.v)
+		}
+		if got, err := tc.le(bytes.NewReader(rev)); err != nil || got != tc.v {
+			t.Errorf("%s LE: %x: unexpected read: got %d, %v, want %d", tc.name, rev, got, err, tc.v)
+		}
+		if _, err := tc.le(bytes.NewReader(nil)); !errors.Is(err, io.EOF) {
+			t.Errorf("%s LE: unexpected error: got %v, want %q", tc.name, err, io.EOF)
+		}
+		if _, err := tc.be(bytes.NewReader(b[:len(b)-1])); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("%s BE: unexpected error: got %v, want %q", tc.name, err, io.ErrUnexpectedEOF)
+		}
+	}
+}
+
+func TestWriteUintN(t *testing.T) {
+	type fn = func(io.Writer, uint64) error
+	u24 := func(f func(io.Writer, uint32) error) fn {
+		return func(w io.Writer, v uint64) error { return f(w, uint32(v)) }
+	}
+	tcs := []struct {
+		name   string
+		be, le fn
+		v      uint64
+		b      string
+		e      error
+	}{
+		{"24", u24(typeio.WriteUint24BE), u24(typeio.WriteUint24LE), 0, "000000", nil},
+		{"24", u24(typeio.WriteUint24BE), u24(typeio.WriteUint24LE), 0x123456, "123456", nil},
+		{"24", u24(typeio.WriteUint24BE), u24(typeio.WriteUint24LE), 0xffffff, "ffffff", nil},
+		{"24", u24(typeio.WriteUint24BE), u24(typeio.WriteUint24LE), 0x1000000, "", typeio.ErrValueOutOfRange},
+		{"40", typeio.WriteUint40BE, typeio.WriteUint40LE, 0xffffffffff, "ffffffffff", nil},
+		{"40", typeio.WriteUint40BE, typeio.WriteUint40LE, 0x10000000000, "", typeio.ErrValueOutOfRange},
+		{"48", typeio.WriteUint48BE, typeio.WriteUint48LE, 0x123456789abc, "123456789abc", nil},
+		{"48", typeio.WriteUint48BE, typeio.WriteUint48LE, 0x1000000000000, "", typeio.ErrValueOutOfRange},
+		{"56", typeio.WriteUint56BE, typeio.WriteUint56LE, 0xffffffffffffff, "ffffffffffffff", nil},
+		{"56", typeio.WriteUint56BE, typeio.WriteUint56LE, 0x100000000000000, "", typeio.ErrValueOutOfRange},
+	}
+	for _, tc := range tcs {
+		for i, f := range []fn{tc.be, tc.le} {
+			w := new(bytes.Buffer)
+			err := f(w, tc.v)
+			switch {
+			case tc.e != nil && err == nil:
+				t.Errorf("%s(%d): %x: error expected.", tc.name, i, tc.v)
+			case tc.e != nil && !errors.Is(err, tc.e):
+				t.Errorf("%s(%d): %x: unexpected type of error: got %q, want %q", tc.name, i, tc.v, err, tc.e)
+			case tc.e == nil && err != nil:
+				t.Errorf("%s(%d): %x: unexpected error: %s", tc.name, i, tc.v, err)
+			}
+			got := w.Bytes()
+			if i == 1 {
+				for j := 0; j < len(got)/2; j++ {
+					got[j], got[len(got)-1-j] = got[len(got)-1-j], got[j]
+				}
+			}
+			if got := hex.EncodeToString(got); got != tc.b {
+				t.Errorf("%s(%d): %x: unexpected write: got %s, want %s", tc.name, i, tc.v, got, tc.b)
+			}
+		}
+	}
+}
+
+func TestWriteIntN(t *testing.T) {
+	type fn = func(io.Writer, int64) error
+	i24 := func(f func(io.Writer, int32) error) fn {
+		return func(w io.Writer, v int64) error { return f(w, int32(v)) }
+	}
+	tcs := []struct {
+		name   string
+		be, le fn
+		v      int64
+		b      string
+		e      error
+	}{
+		{"24", i24(typeio.WriteInt24BE), i24(typeio.WriteInt24LE), 0, "000000", nil},
+		{"24", i24(typeio.WriteInt24BE), i24(typeio.WriteInt24LE), -1, "ffffff", nil},
+		{"24", i24(typeio.WriteInt24BE), i24(typeio.WriteInt24LE), 1<<23 - 1, "7fffff", nil},
+		{"24", i24(typeio.WriteInt24BE), i24(typeio.WriteInt24LE), -1 << 23, "800000", nil},
+		{"24", i24(typeio.WriteInt24BE), i24(typeio.WriteInt24LE), 1 << 23, "", typeio.ErrValueOutOfRange},
+		{"24", i24(typeio.WriteInt24BE), i24(typeio.WriteInt24LE), -1<<23 - 1, "", typeio.ErrValueOutOfRange},
+		{"40", typeio.WriteInt40BE, typeio.WriteInt40LE, -2, "fffffffffe", nil},
+		{"40", typeio.WriteInt40BE, typeio.WriteInt40LE, 1 << 39, "", typeio.ErrValueOutOfRange},
+		{"48", typeio.WriteInt48BE, typeio.WriteInt48LE, -1 << 47, "800000000000", nil},
+		{"48", typeio.WriteInt48BE, typeio.WriteInt48LE, -1<<47 - 1, "", typeio.ErrValueOutOfRange},
+		{"56", typeio.WriteInt56BE, typeio.WriteInt56LE, 1<<55 - 1, "7fffffffffffff", nil},
+		{"56", typeio.WriteInt56BE, typeio.WriteInt56LE, 1 << 55, "", typeio.ErrValueOutOfRange},
+	}
+	for _, tc := range tcs {
+		for i, f := range []fn{tc.be, tc.le} {
+			w := new(bytes.Buffer)
+			err := f(w, tc.v)
+			switch {
+			case tc.e != nil && err == nil:
+				t.Errorf("%s(%d): %d: error expected.", tc.name, i, tc.v)
+			case tc.e != nil && !errors.Is(err, tc.e):
+				t.Errorf("%s(%d): %d: unexpected type of error: got %q, want %q", tc.name, i, tc.v, err, tc.e)
+			case tc.e == nil && err != nil:
+				t.Errorf("%s(%d): %d: unexpected error: %s", tc.name, i, tc.v, err)
+			}
+			got := w.Bytes()
+			if i == 1 {
+				for j := 0; j < len(got)/2; j++ {
+					got[j], got[len(got)-1-j] = got[len(got)-1-j], got[j]
+				}
+			}
+			if got := hex.EncodeToString(got); got != tc.b {
+				t.Errorf("%s(%d): %d: unexpected write: got %s, want %s", tc.name, i, tc.v, got, tc.b)
+			}
+		}
+	}
+}
+
+func TestUint128(t *testing.T) {
+	tcs := []struct {
+		be string
+		le string
+		v  typeio.Uint128
+	}{
+		{"00000000000000000000000000000000", "00000000000000000000000000000000", typeio.Uint128{}},
+		{"00000000000000000000000000000001", "01000000000000000000000000000000", typeio.Uint128{Lo: 1}},
+		{"00000000000000010000000000000000", "00000000000000000100000000000000", typeio.Uint128{Hi: 1}},
+		{"0123456789abcdeffedcba9876543210", "1032547698badcfeefcdab8967452301", typeio.Uint128{Hi: 0x0123456789abcdef, Lo: 0xfedcba9876543210}},
+	}
+	for _, tc := range tcs {
+		be, _ := hex.DecodeString(tc.be)
+		le, _ := hex.DecodeString(tc.le)
+		if v, err := typeio.ReadUint128BE(bytes.NewReader(be)); err != nil || v != tc.v {
+			t.Errorf("BE: %q: unexpected read: got %x, %v, want %x", tc.be, v, err, tc.v)
+		}
+		if v, err := typeio.ReadUint128LE(bytes.NewReader(le)); err != nil || v != tc.v {
+			t.Errorf("LE: %q: unexpected read: got %x, %v, want %x", tc.le, v, err, tc.v)
+		}
+		w := new(bytes.Buffer)
+		if err := typeio.WriteUint128BE(w, tc.v); err != nil {
+			t.Errorf("BE: %x: unexpected error: %s", tc.v, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.be {
+			t.Errorf("BE: %x: unexpected write: got %s, want %s", tc.v, got, tc.be)
+		}
+		w.Reset()
+		if err := typeio.WriteUint128LE(w, tc.v); err != nil {
+			t.Errorf("LE: %x: unexpected error: %s", tc.v, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.le {
+			t.Errorf("LE: %x: unexpected write: got %s, want %s", tc.v, got, tc.le)
+		}
+	}
+	if _, err := typeio.ReadUint128BE(bytes.NewReader(nil)); !errors.Is(err, io.EOF) {
+		t.Errorf("unexpected error: got %v, want %v", err, io.EOF)
+	}
+	if _, err := typeio.ReadUint128LE(bytes.NewReader(make([]byte, 8))); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("unexpected error: got %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrOverflow is the error thrown when an encoded value is too large or too
+// long to be represented by the result type.
+var ErrOverflow = errors.New("value overflows")
+
+// maxLEB128Len is the maximum length of a 64-bit value in LEB128 encoding.
+const maxLEB128Len = 10
+
+// ReadULEB128 reads an unsigned LEB128 encoded value from r and returns it as a
+// uint64 value. The second return value is the number of bytes read. Redundant
+// padding bytes are accepted as long as the encoding does not exceed 10 bytes.
+// ErrOverflow is returned if the encoded value does not fit in uint64.
+func ReadULEB128(r io.Reader) (uint64, int, error) {
+	var v uint64
+	for n := 0; ; n++ {
+		c, err := readByte(r)
+		if err != nil {
+			if 0 < n {
+				err = noEOF(err)
+			}
+			return 0, n, err
+		}
+		if n == maxLEB128Len-1 && 1 < c {
+			return 0, n + 1, ErrOverflow
+		}
+		v |= uint64(c&0x7f) << (7 * n)
+		if c&0x80 == 0 {
+			return v, n + 1, nil
+		}
+	}
+}
+
+// WriteULEB128 writes the value v of uint64 to w in unsigned LEB128 encoding
+// using the minimum number of bytes. It returns the number of bytes written.
+func WriteULEB128(w io.Writer, v uint64) (int, error) {
+	var b [maxLEB128Len]byte
+	n := 0
+	for {
+		c := byte(v & 0x7f)
+		v >>= 7
+		if v == 0 {
+			b[n] = c
+			n++
+			break
+		}
+		b[n] = c | 0x80
+		n++
+	}
+	if err := write(w, b[:n]); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// ReadSLEB128 reads a signed LEB128 encoded value from r and returns it as an
+// int64 value. The second return value is the number of bytes read. Redundant
+// padding bytes are accepted as long as the encoding does not exceed 10 bytes.
+// ErrOverflow is returned if the encoded value does not fit in int64.
+func ReadSLEB128(r io.Reader) (int64, int, error) {
+	var v int64
+	for n := 0; ; n++ {
+		c, err := readByte(r)
+		if err != nil {
+			if 0 < n {
+				err = noEOF(err)
+			}
+			return 0, n, err
+		}
+		if n == maxLEB128Len-1 && c != 0x00 && c != 0x7f {
+			return 0, n + 1, ErrOverflow
+		}
+		shift := 7 * n
+		v |= int64(c&0x7f) << shift
+		if c&0x80 == 0 {
+			if shift += 7; shift < 64 && c&0x40 != 0 {
+				v |= -1 << shift
+			}
+			return v, n + 1, nil
+		}
+	}
+}
+
+// WriteSLEB128 writes the value v of int64 to w in signed LEB128 encoding
+// using the minimum number of bytes. It returns the number of bytes written.
+func WriteSLEB128(w io.Writer, v int64) (int, error) {
+	var b [maxLEB128Len]byte
+	n := 0
+	for {
+		c := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && c&0x40 == 0) || (v == -1 && c&0x40 != 0) {
+			b[n] = c
+			n++
+			break
+		}
+		b[n] = c | 0x80
+		n++
+	}
+	if err := write(w, b[:n]); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func ExampleReadULEB128() {
+	b, _ := hex.DecodeString("02e58e267f")
+	r := bytes.NewReader(b)
+
+	for {
+		v, n, err := typeio.ReadULEB128(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			panic(err)
+		}
+		fmt.Println(n, v)
+	}
+
+	// Output:
+	// 1 2
+	// 3 624485
+	// 1 127
+}
+
+func ExampleWriteULEB128() {
+	w := new(bytes.Buffer)
+
+	data := []uint64{2, 624485, 127}
+	for _, v := range data {
+		if _, err := typeio.WriteULEB128(w, v); err != nil {
+			panic(err)
+		}
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	// Output:
+	// 02e58e267f
+}
+
+func ExampleReadSLEB128() {
+	b, _ := hex.DecodeString("02c0bb787f")
+	r := bytes.NewReader(b)
+
+	for {
+		v, n, err := typeio.ReadSLEB128(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			panic(err)
+		}
+		fmt.Println(n, v)
+	}
+
+	// Output:
+	// 1 2
+	// 3 -123456
+	// 1 -1
+}
+
+func ExampleWriteSLEB128() {
+	w := new(bytes.Buffer)
+
+	data := []int64{2, -123456, -1}
+	for _, v := range data {
+		if _, err := typeio.WriteSLEB128(w, v); err != nil {
+			panic(err)
+		}
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	// Output:
+	// 02c0bb787f
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func TestReadULEB128(t *testing.T) {
+	tcs := []struct {
+		b string
+		v uint64
+		n int
+		e error
+	}{
+		{"00", 0, 1, nil},
+		{"01", 1, 1, nil},
+		{"7f", 127, 1, nil},
+		{"8001", 128, 2, nil},
+		{"e58e26", 624485, 3, nil},
+		{"e58e2600", 624485, 3, nil},
+		{"8000", 0, 2, nil},
+		{"ffffffffffffffffff01", math.MaxUint64, 10, nil},
+		{"ffffffffffffffff7f", math.MaxUint64 >> 1, 9, nil},
+		{"80808080808080808000", 0, 10, nil},
+		{"ffffffffffffffffff02", 0, 10, typeio.ErrOverflow},
+		{"ffffffffffffffffff81", 0, 10, typeio.ErrOverflow},
+		{"8080808080808080808000", 0, 10, typeio.ErrOverflow},
+		{"", 0, 0, io.EOF},
+		{"80", 0, 1, io.ErrUnexpectedEOF},
+		{"e58e", 0, 2, io.ErrUnexpectedEOF},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		r := bytes.NewReader(b)
+		got, n, err := typeio.ReadULEB128(r)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%q: error expected: got %d", tc.b, got)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%q: unexpected type of error: got %q, want %q", tc.b, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%q: unexpected error: %s", tc.b, err)
+		case tc.e == nil && got != tc.v:
+			t.Errorf("%q: unexpected read: got %d, want %d", tc.b, got, tc.v)
+		}
+		if n != tc.n {
+			t.Errorf("%q: unexpected read len: got %d, want %d", tc.b, n, tc.n)
+		}
+	}
+}
+
+func TestWriteULEB128(t *testing.T) {
+	tcs := []struct {
+		v uint64
+		b string
+	}{
+		{0, "00"},
+		{1, "01"},
+		{127, "7f"},
+		{128, "8001"},
+		{624485, "e58e26"},
+		{math.MaxUint64 >> 1, "ffffffffffffffff7f"},
+		{math.MaxUint64, "ffffffffffffffffff01"},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		n, err := typeio.WriteULEB128(w, tc.v)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %s", tc.v, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%d: unexpected write: got %s, want %s", tc.v, got, tc.b)
+		}
+		if n != len(tc.b)/2 {
+			t.Errorf("%d: unexpected write len: got %d, want %d", tc.v, n, len(tc.b)/2)
+		}
+	}
+}
+
+func TestReadSLEB128(t *testing.T) {
+	tcs := []struct {
+		b string
+		v int64
+		n int
+		e error
+	}{
+		{"00", 0, 1, nil},
+		{"01", 1, 1, nil},
+		{"3f", 63, 1, nil},
+		{"7f", -1, 1, nil},
+		{"40", -64, 1, nil},
+		{"c000", 64, 2, nil},
+		{"807f", -128, 2, nil},
+		{"c0bb78", -123456, 3, nil},
+		{"ff7f", -1, 2, nil},
+		{"ffffffffffffffffff00", math.MaxInt64, 10, nil},
+		{"8080808080808080807f", math.MinInt64, 10, nil},
+		{"ffffffffffffffffff7f", -1, 10, nil},
+		{"ffffffffffffffffff01", 0, 10, typeio.ErrOverflow},
+		{"8080808080808080807e", 0, 10, typeio.ErrOverflow},
+		{"ffffffffffffffffffff7f", 0, 10, typeio.ErrOverflow},
+		{"", 0, 0, io.EOF},
+		{"ff", 0, 1, io.ErrUnexpectedEOF},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		r := bytes.NewReader(b)
+		got, n, err := typeio.ReadSLEB128(r)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%q: error expected: got %d", tc.b, got)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%q: unexpected type of error: got %q, want %q", tc.b, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%q: unexpected error: %s", tc.b, err)
+		case tc.e == nil && got != tc.v:
+			t.Errorf("%q: unexpected read: got %d, want %d", tc.b, got, tc.v)
+		}
+		if n != tc.n {
+			t.Errorf("%q: unexpected read len: got %d, want %d", tc.b, n, tc.n)
+		}
+	}
+}
+
+func TestWriteSLEB128(t *testing.T) {
+	tcs := []struct {
+		v int64
+		b string
+	}{
+		{0, "00"},
+		{1, "01"},
+		{63, "3f"},
+		{64, "c000"},
+		{-1, "7f"},
+		{-64, "40"},
+		{-65, "bf7f"},
+		{-128, "807f"},
+		{-123456, "c0bb78"},
+		{math.MaxInt64, "ffffffffffffffffff00"},
+		{math.MinInt64, "8080808080808080807f"},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		n, err := typeio.WriteSLEB128(w, tc.v)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %s", tc.v, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%d: unexpected write: got %s, want %s", tc.v, got, tc.b)
+		}
+		if n != len(tc.b)/2 {
+			t.Errorf("%d: unexpected write len: got %d, want %d", tc.v, n, len(tc.b)/2)
+		}
+	}
+}
+
+func TestULEB128_roundTrip(t *testing.T) {
+	for _, v := range []uint64{0, 1, 1 << 7, 1<<14 - 1, 1 << 35, 1<<63 + 12345} {
+		w := new(bytes.Buffer)
+		if _, err := typeio.WriteULEB128(w, v); err != nil {
+			t.Fatalf("%d: unexpected error: %s", v, err)
+		}
+		got, _, err := typeio.ReadULEB128(w)
+		if err != nil {
+			t.Fatalf("%d: unexpected error: %s", v, err)
+		}
+		if got != v {
+			t.Errorf("unexpected read: got %d, want %d", got, v)
+		}
+	}
+	for _, v := range []int64{0, -1, 1 << 40, -1 << 40, -1<<63 + 1, 1<<62 - 7} {
+		w := new(bytes.Buffer)
+		if _, err := typeio.WriteSLEB128(w, v); err != nil {
+			t.Fatalf("%d: unexpected error: %s", v, err)
+		}
+		got, _, err := typeio.ReadSLEB128(w)
+		if err != nil {
+			t.Fatalf("%d: unexpected error: %s", v, err)
+		}
+		if got != v {
+			t.Errorf("unexpected read: got %d, want %d", got, v)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+)
+
+// ErrInvalidPrefix is the error thrown when an invalid IP prefix is specified
+// or read.
+var ErrInvalidPrefix = errors.New("invalid IP prefix")
+
+// ReadAddr4 reads 4 bytes from r and returns them as an IPv4 address.
+func ReadAddr4(r io.Reader) (netip.Addr, error) {
+	var b [4]byte
+	if err := readFull(r, b[:]); err != nil {
+		return netip.Addr{}, err
+	}
+	return netip.AddrFrom4(b), nil
+}
+
+// WriteAddr4 writes 4 bytes to w that represent the IPv4 address addr. As with
+// WriteIPv4, the addr can be an IPv4-mapped IPv6 address without a zone.
+// ErrInvalidIP is returned if addr is not an IPv4 address.
+func WriteAddr4(w io.Writer, addr netip.Addr) error {
+	if addr.Zone() != "" || !addr.Unmap().Is4() {
+		return fmt.Errorf("%w: %v is not IPv4", ErrInvalidIP, addr)
+	}
+	b := addr.Unmap().As4()
+	return write(w, b[:])
+}
+
+// ReadAddr6 reads 16 bytes from r and returns them as an IPv6 address. An
+// IPv4-mapped IPv6 address is returned as is. Use ReadAddr6Unmap to get it as
+// an IPv4 address.
+func ReadAddr6(r io.Reader) (netip.Addr, error) {
+	var b [16]byte
+	if err := readFull(r, b[:]); err != nil {
+		return netip.Addr{}, err
+	}
+	return netip.AddrFrom16(b), nil
+}
+
+// ReadAddr6Unmap is identical to ReadAddr6 except that it returns an
+// IPv4-mapped IPv6 address as an IPv4 address.
+func ReadAddr6Unmap(r io.Reader) (netip.Addr, error) {
+	addr, err := ReadAddr6(r)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return addr.Unmap(), nil
+}
+
+// WriteAddr6 writes 16 bytes to w that represent the IPv6 address addr. As with
+// WriteIPv6, the addr can be an IPv4 address, and it will be written as an
+// IPv4-mapped IPv6. ErrInvalidIP is returned if addr is not valid or has a
+// zone, since the zone can not be represented in the format.
+func WriteAddr6(w io.Writer, addr netip.Addr) error {
+	switch {
+	case !addr.IsValid():
+		return fmt.Errorf("%w: zero Addr", ErrInvalidIP)
+	case addr.Zone() != "":
+		return fmt.Errorf("%w: %v has a zone", ErrInvalidIP, addr)
+	}
+	b := addr.As16()
+	return write(w, b[:])
+}
+
+// ReadPrefix4 reads 5 bytes from r, an IPv4 address followed by a prefix
+// length byte, and returns them as an IPv4 prefix. The bits outside the prefix
+// are returned as is. ErrInvalidPrefix is returned if the prefix length is
+// greater than 32.
+func ReadPrefix4(r io.Reader) (netip.Prefix, error) {
+	var b [5]byte
+	if err := readFull(r, b[:]); err != nil {
+		return netip.Prefix{}, err
+	}
+	if 32 < b[4] {
+		return netip.Prefix{}, fmt.Errorf("%w: IPv4 prefix length %d", ErrInvalidPrefix, b[4])
+	}
+	return netip.PrefixFrom(netip.AddrFrom4(*(*[4]byte)(b[:4])), int(b[4])), nil
+}
+
+// WritePrefix4 writes 5 bytes to w that represent the IPv4 prefix p, the
+// address followed by the prefix length byte. As with WriteAddr4, p can be an
+// IPv4-mapped IPv6 prefix, whose length is reduced by 96. The bits of the
+// address outside the prefix are written as is; use p.Masked() in advance to
+// clear them. ErrInvalidPrefix is returned if p is not a valid IPv4 prefix.
+func WritePrefix4(w io.Writer, p netip.Prefix) error {
+	addr, bits := p.Addr(), p.Bits()
+	if addr.Is4In6() {
+		addr, bits = addr.Unmap(), bits-96
+	}
+	if !p.IsValid() || !addr.Is4() || bits < 0 {
+		return fmt.Errorf("%w: %v is not IPv4", ErrInvalidPrefix, p)
+	}
+	var b [5]byte
+	*(*[4]byte)(b[:4]) = addr.As4()
+	b[4] = byte(bits)
+	return write(w, b[:])
+}
+
+// ReadPrefix6 reads 17 bytes from r, an IPv6 address followed by a prefix
+// length byte, and returns them as an IPv6 prefix. The bits outside the prefix
+// are returned as is. ErrInvalidPrefix is returned if the prefix length is
+// greater than 128.
+func ReadPrefix6(r io.Reader) (netip.Prefix, error) {
+	var b [17]byte
+	if err := readFull(r, b[:]); err != nil {
+		return netip.Prefix{}, err
+	}
+	if 128 < b[16] {
+		return netip.Prefix{}, fmt.Errorf("%w: IPv6 prefix length %d", ErrInvalidPrefix, b[16])
+	}
+	return netip.PrefixFrom(netip.AddrFrom16(*(*[16]byte)(b[:16])), int(b[16])), nil
+}
+
+// ReadPrefix6Unmap is identical to ReadPrefix6 except that it returns a prefix
+// of an IPv4-mapped IPv6 address with the prefix length 96 or greater as an
+// IPv4 prefix with the prefix length reduced by 96. Other prefixes, including
+// those of IPv4-mapped IPv6 addresses with a shorter prefix length, are
+// returned as IPv6 prefixes.
+func ReadPrefix6Unmap(r io.Reader) (netip.Prefix, error) {
+	p, err := ReadPrefix6(r)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	if addr := p.Addr(); addr.Is4In6() && 96 <= p.Bits() {
+		return netip.PrefixFrom(addr.Unmap(), p.Bits()-96), nil
+	}
+	return p, nil
+}
+
+// WritePrefix6 writes 17 bytes to w that represent the IPv6 prefix p, the
+// address followed by the prefix length byte. The p can be an IPv4 prefix, and
+// it will be written as an IPv4-mapped IPv6 prefix with the prefix length
+// increased by 96. The bits of the address outside the prefix are written as
+// is; use p.Masked() in advance to clear them. ErrInvalidPrefix is returned if
+// p is not valid.
+func WritePrefix6(w io.Writer, p netip.Prefix) error {
+	if !p.IsValid() {
+		return fmt.Errorf("%w: %v", ErrInvalidPrefix, p)
+	}
+	bits := p.Bits()
+	if p.Addr().Is4() {
+		bits += 96
+	}
+	var b [17]byte
+	*(*[16]byte)(b[:16]) = p.Addr().As16()
+	b[16] = byte(bits)
+	return write(w, b[:])
+}
+
+// ReadAddrPort4 reads 6 bytes from r, an IPv4 address followed by a port
+// number in big-endian byte order, and returns them as a netip.AddrPort.
+func ReadAddrPort4(r io.Reader) (netip.AddrPort, error) {
+	var b [6]byte
+	if err := readFull(r, b[:]); err != nil {
+		return netip.AddrPort{}, err
+	}
+	addr := netip.AddrFrom4(*(*[4]byte)(b[:4]))
+	return netip.AddrPortFrom(addr, binary.BigEndian.Uint16(b[4:])), nil
+}
+
+// WriteAddrPort4 writes 6 bytes to w that represent ap, the IPv4 address
+// followed by the port number in big-endian byte order. As with WriteAddr4,
+// the address can be an IPv4-mapped IPv6 address without a zone. ErrInvalidIP
+// is returned if the address is not an IPv4 address.
+func WriteAddrPort4(w io.Writer, ap netip.AddrPort) error {
+	addr := ap.Addr()
+	if addr.Zone() != "" || !addr.Unmap().Is4() {
+		return fmt.Errorf("%w: %v is not IPv4", ErrInvalidIP, addr)
+	}
+	var b [6]byte
+	*(*[4]byte)(b[:4]) = addr.Unmap().As4()
+	binary.BigEndian.PutUint16(b[4:], ap.Port())
+	return write(w, b[:])
+}
+
+// ReadAddrPort6 reads 18 bytes from r, an IPv6 address followed by a port
+// number in big-endian byte order, and returns them as a netip.AddrPort. An
+// IPv4-mapped IPv6 address is returned as is.
+func ReadAddrPort6(r io.Reader) (netip.AddrPort, error) {
+	var b [18]byte
+	if err := readFull(r, b[:]); err != nil {
+		return netip.AddrPort{}, err
+	}
+	addr := netip.AddrFrom16(*(*[16]byte)(b[:16]))
+	return netip.AddrPortFrom(addr, binary.BigEndian.Uint16(b[16:])), nil
+}
+
+// ReadAddrPort6Unmap is identical to ReadAddrPort6 except that it returns an
+// IPv4-mapped IPv6 address as an IPv4 address.
+func ReadAddrPort6Unmap(r io.Reader) (netip.AddrPort, error) {
+	ap, err := ReadAddrPort6(r)
+	if err != nil {
+		return netip.AddrPort{}, err
+	}
+	return netip.AddrPortFrom(ap.Addr().Unmap(), ap.Port()), nil
+}
+
+// WriteAddrPort6 writes 18 bytes to w that represent ap, the IPv6 address
+// followed by the port number in big-endian byte order. As with WriteAddr6, the
+// address can be an IPv4 address, and it will be written as an IPv4-mapped
+// IPv6. ErrInvalidIP is returned if the address is not valid or has a zone.
+func WriteAddrPort6(w io.Writer, ap netip.AddrPort) error {
+	addr := ap.Addr()
+	switch {
+	case !addr.IsValid():
+		return fmt.Errorf("%w: zero Addr", ErrInvalidIP)
+	case addr.Zone() != "":
+		return fmt.Errorf("%w: %v has a zone", ErrInvalidIP, addr)
+	}
+	var b [18]byte
+	*(*[16]byte)(b[:16]) = addr.As16()
+	binary.BigEndian.PutUint16(b[16:], ap.Port())
+	return write(w, b[:])
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func ExampleReadAddr6Unmap() {
+	b1 := "20010db8000000000000000012345678"
+	b2 := "00000000000000000000ffffc0000280"
+	b, _ := hex.DecodeString(b1 + b2)
+	r := bytes.NewReader(b)
+
+	for {
+		v, err := typeio.ReadAddr6Unmap(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			panic(err)
+		}
+		fmt.Println(v)
+	}
+
+	// Output:
+	// 2001:db8::1234:5678
+	// 192.0.2.128
+}
+
+func ExampleWritePrefix4() {
+	w := new(bytes.Buffer)
+
+	data := []netip.Prefix{
+		netip.MustParsePrefix("192.0.2.0/24"),
+		netip.MustParsePrefix("198.51.100.128/25"),
+	}
+	for _, p := range data {
+		if err := typeio.WritePrefix4(w, p); err != nil {
+			panic(err)
+		}
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	// Output:
+	// c000020018c633648019
+}
+
+func ExampleWriteAddrPort6() {
+	w := new(bytes.Buffer)
+
+	data := []netip.AddrPort{
+		netip.MustParseAddrPort("[2001:db8::1]:443"),
+		netip.MustParseAddrPort("[fe80::1%eth0]:443"),
+	}
+	for _, ap := range data {
+		if err := typeio.WriteAddrPort6(w, ap); err != nil {
+			fmt.Println(err)
+		}
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	// Output:
+	// invalid IP address: fe80::1%eth0 has a zone
+	// 20010db800000000000000000000000101bb
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/netip"
+	"testing"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func TestReadAddr(t *testing.T) {
+	tcs := []struct {
+		name string
+		fn   func(io.Reader) (netip.Addr, error)
+		b    string
+		a    netip.Addr
+		e    error
+	}{
+		{"4", typeio.ReadAddr4, "c0000201", netip.MustParseAddr("192.0.2.1"), nil},
+		{"4", typeio.ReadAddr4, "", netip.Addr{}, io.EOF},
+		{"4", typeio.ReadAddr4, "c00002", netip.Addr{}, io.ErrUnexpectedEOF},
+		{"6", typeio.ReadAddr6, "20010db8000000000000000012345678", netip.MustParseAddr("2001:db8::1234:5678"), nil},
+		{"6", typeio.ReadAddr6, "00000000000000000000ffffc0000201", netip.MustParseAddr("::ffff:192.0.2.1"), nil},
+		{"6", typeio.ReadAddr6, "", netip.Addr{}, io.EOF},
+		{"6", typeio.ReadAddr6, "20010db8", netip.Addr{}, io.ErrUnexpectedEOF},
+		{"6Unmap", typeio.ReadAddr6Unmap, "20010db8000000000000000012345678", netip.MustParseAddr("2001:db8::1234:5678"), nil},
+		{"6Unmap", typeio.ReadAddr6Unmap, "00000000000000000000ffffc0000201", netip.MustParseAddr("192.0.2.1"), nil},
+		{"6Unmap", typeio.ReadAddr6Unmap, "20010db8", netip.Addr{}, io.ErrUnexpectedEOF},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		got, err := tc.fn(bytes.NewReader(b))
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%s: %q: error expected: got %s", tc.name, tc.b, got)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%s: %q: unexpected type of error: got %q, want %q", tc.name, tc.b, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%s: %q: unexpected error: %s", tc.name, tc.b, err)
+		case tc.e == nil && got != tc.a:
+			t.Errorf("%s: %q: unexpected read: got %s, want %s", tc.name, tc.b, got, tc.a)
+		}
+	}
+}
+
+func TestWriteAddr(t *testing.T) {
+	tcs := []struct {
+		name string
+		fn   func(io.Writer, netip.Addr) error
+		a    netip.Addr
+		b    string
+		e    error
+	}{
+		{"4", typeio.WriteAddr4, netip.MustParseAddr("192.0.2.1"), "c0000201", nil},
+		{"4", typeio.WriteAddr4, netip.MustParseAddr("::ffff:192.0.2.1"), "c0000201", nil},
+		{"4", typeio.WriteAddr4, netip.MustParseAddr("::ffff:192.0.2.1%eth0"), "", typeio.ErrInvalidIP},
+		{"4", typeio.WriteAddr4, netip.MustParseAddr("2001:db8::1"), "", typeio.ErrInvalidIP},
+		{"4", typeio.WriteAddr4, netip.Addr{}, "", typeio.ErrInvalidIP},
+		{"6", typeio.WriteAddr6, netip.MustParseAddr("2001:db8::1234:5678"), "20010db8000000000000000012345678", nil},
+		{"6", typeio.WriteAddr6, netip.MustParseAddr("192.0.2.1"), "00000000000000000000ffffc0000201", nil},
+		{"6", typeio.WriteAddr6, netip.MustParseAddr("fe80::1%eth0"), "", typeio.ErrInvalidIP},
+		{"6", typeio.WriteAddr6, netip.Addr{}, "", typeio.ErrInvalidIP},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		err := tc.fn(w, tc.a)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%s: %s: error expected.", tc.name, tc.a)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%s: %s: unexpected type of error: got %q, want %q", tc.name, tc.a, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%s: %s: unexpected error: %s", tc.name, tc.a, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%s: %s: unexpected write: got %s, want %s", tc.name, tc.a, got, tc.b)
+		}
+	}
+}
+
+func TestReadPrefix(t *testing.T) {
+	tcs := []struct {
+		name string
+		fn   func(io.Reader) (netip.Prefix, error)
+		b    string
+		p    netip.Prefix
+		e    error
+	}{
+		{"4", typeio.ReadPrefix4, "c000020018", netip.MustParsePrefix("192.0.2.0/24"), nil},
+		{"4", typeio.ReadPrefix4, "c000020120", netip.MustParsePrefix("192.0.2.1/32"), nil},
+		{"4", typeio.ReadPrefix4, "c000020118", netip.MustParsePrefix("192.0.2.1/24"), nil},
+		{"4", typeio.ReadPrefix4, "0000000000", netip.MustParsePrefix("0.0.0.0/0"), nil},
+		{"4", typeio.ReadPrefix4, "c000020121", netip.Prefix{}, typeio.ErrInvalidPrefix},
+		{"4", typeio.ReadPrefix4, "", netip.Prefix{}, io.EOF},
+		{"4", typeio.ReadPrefix4, "c0000200", netip.Prefix{}, io.ErrUnexpectedEOF},
+		{"6", typeio.ReadPrefix6, "20010db800000000000000000000000020", netip.MustParsePrefix("2001:db8::/32"), nil},
+		{"6", typeio.ReadPrefix6, "00000000000000000000ffffc000020078", netip.MustParsePrefix("::ffff:192.0.2.0/120"), nil},
+		{"6", typeio.ReadPrefix6, "20010db800000000000000000000000081", netip.Prefix{}, typeio.ErrInvalidPrefix},
+		{"6", typeio.ReadPrefix6, "20010db8000000000000000000000000", netip.Prefix{}, io.ErrUnexpectedEOF},
+		{"6Unmap", typeio.ReadPrefix6Unmap, "00000000000000000000ffffc000020078", netip.MustParsePrefix("192.0.2.0/24"), nil},
+		{"6Unmap", typeio.ReadPrefix6Unmap, "00000000000000000000ffffc000020060", netip.MustParsePrefix("192.0.2.0/0"), nil},
+		{"6Unmap", typeio.ReadPrefix6Unmap, "00000000000000000000ffff0000000050", netip.MustParsePrefix("::ffff:0.0.0.0/80"), nil},
+		{"6Unmap", typeio.ReadPrefix6Unmap, "20010db800000000000000000000000020", netip.MustParsePrefix("2001:db8::/32"), nil},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		got, err := tc.fn(bytes.NewReader(b))
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%s: %q: error expected: got %s", tc.name, tc.b, got)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%s: %q: unexpected type of error: got %q, want %q", tc.name, tc.b, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%s: %q: unexpected error: %s", tc.name, tc.b, err)
+		case tc.e == nil && got != tc.p:
+			t.Errorf("%s: %q: unexpected read: got %s, want %s", tc.name, tc.b, got, tc.p)
+		}
+	}
+}
+
+func TestWritePrefix(t *testing.T) {
+	tcs := []struct {
+		name string
+		fn   func(io.Writer, netip.Prefix) error
+		p    netip.Prefix
+		b    string
+		e    error
+	}{
+		{"4", typeio.WritePrefix4, netip.MustParsePrefix("192.0.2.0/24"), "c000020018", nil},
+		{"4", typeio.WritePrefix4, netip.MustParsePrefix("192.0.2.1/24"), "c000020118", nil},
+		{"4", typeio.WritePrefix4, netip.MustParsePrefix("0.0.0.0/0"), "0000000000", nil},
+		{"4", typeio.WritePrefix4, netip.MustParsePrefix("2001:db8::/32"), "", typeio.ErrInvalidPrefix},
+		{"4", typeio.WritePrefix4, netip.MustParsePrefix("::ffff:192.0.2.0/120"), "c000020018", nil},
+		{"4", typeio.WritePrefix4, netip.MustParsePrefix("::ffff:0.0.0.0/96"), "0000000000", nil},
+		{"4", typeio.WritePrefix4, netip.MustParsePrefix("::ffff:0.0.0.0/80"), "", typeio.ErrInvalidPrefix},
+		{"4", typeio.WritePrefix4, netip.Prefix{}, "", typeio.ErrInvalidPrefix},
+		{"6", typeio.WritePrefix6, netip.MustParsePrefix("2001:db8::/32"), "20010db800000000000000000000000020", nil},
+		{"6", typeio.WritePrefix6, netip.MustParsePrefix("192.0.2.0/24"), "00000000000000000000ffffc000020078", nil},
+		{"6", typeio.WritePrefix6, netip.MustParsePrefix("::ffff:192.0.2.0/120"), "00000000000000000000ffffc000020078", nil},
+		{"6", typeio.WritePrefix6, netip.Prefix{}, "", typeio.ErrInvalidPrefix},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		err := tc.fn(w, tc.p)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%s: %s: error expected.", tc.name, tc.p)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%s: %s: unexpected type of error: got %q, want %q", tc.name, tc.p, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%s: %s: unexpected error: %s", tc.name, tc.p, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%s: %s: unexpected write: got %s, want %s", tc.name, tc.p, got, tc.b)
+		}
+	}
+}
+
+func TestReadAddrPort(t *testing.T) {
+	tcs := []struct {
+		name string
+		fn   func(io.Reader) (netip.AddrPort, error)
+		b    string
+		ap   netip.AddrPort
+		e    error
+	}{
+		{"4", typeio.ReadAddrPort4, "c00002010035", netip.MustParseAddrPort("192.0.2.1:53"), nil},
+		{"4", typeio.ReadAddrPort4, "", netip.AddrPort{}, io.EOF},
+		{"4", typeio.ReadAddrPort4, "c000020100", netip.AddrPort{}, io.ErrUnexpectedEOF},
+		{"6", typeio.ReadAddrPort6, "20010db8000000000000000000000001ffff", netip.MustParseAddrPort("[2001:db8::1]:65535"), nil},
+		{"6", typeio.ReadAddrPort6, "00000000000000000000ffffc00002010050", netip.MustParseAddrPort("[::ffff:192.0.2.1]:80"), nil},
+		{"6", typeio.ReadAddrPort6, "20010db8000000000000000000000001", netip.AddrPort{}, io.ErrUnexpectedEOF},
+		{"6Unmap", typeio.ReadAddrPort6Unmap, "00000000000000000000ffffc00002010050", netip.MustParseAddrPort("192.0.2.1:80"), nil},
+		{"6Unmap", typeio.ReadAddrPort6Unmap, "20010db8000000000000000000000001ffff", netip.MustParseAddrPort("[2001:db8::1]:65535"), nil},
+		{"6Unmap", typeio.ReadAddrPort6Unmap, "", netip.AddrPort{}, io.EOF},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		got, err := tc.fn(bytes.NewReader(b))
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%s: %q: error expected: got %s", tc.name, tc.b, got)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%s: %q: unexpected type of error: got %q, want %q", tc.name, tc.b, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%s: %q: unexpected error: %s", tc.name, tc.b, err)
+		case tc.e == nil && got != tc.ap:
+			t.Errorf("%s: %q: unexpected read: got %s, want %s", tc.name, tc.b, got, tc.ap)
+		}
+	}
+}
+
+func TestWriteAddrPort(t *testing.T) {
+	tcs := []struct {
+		name string
+		fn   func(io.Writer, netip.AddrPort) error
+		ap   netip.AddrPort
+		b    string
+		e    error
+	}{
+		{"4", typeio.WriteAddrPort4, netip.MustParseAddrPort("192.0.2.1:53"), "c00002010035", nil},
+		{"4", typeio.WriteAddrPort4, netip.MustParseAddrPort("[::ffff:192.0.2.1]:53"), "c00002010035", nil},
+		{"4", typeio.WriteAddrPort4, netip.MustParseAddrPort("[2001:db8::1]:53"), "", typeio.ErrInvalidIP},
+		{"4", typeio.WriteAddrPort4, netip.AddrPort{}, "", typeio.ErrInvalidIP},
+		{"6", typeio.WriteAddrPort6, netip.MustParseAddrPort("[2001:db8::1]:65535"), "20010db8000000000000000000000001ffff", nil},
+		{"6", typeio.WriteAddrPort6, netip.MustParseAddrPort("192.0.2.1:80"), "00000000000000000000ffffc00002010050", nil},
+		{"6", typeio.WriteAddrPort6, netip.MustParseAddrPort("[fe80::1%eth0]:80"), "", typeio.ErrInvalidIP},
+		{"6", typeio.WriteAddrPort6, netip.AddrPort{}, "", typeio.ErrInvalidIP},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		err := tc.fn(w, tc.ap)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%s: %s: error expected.", tc.name, tc.ap)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%s: %s: unexpected type of error: got %q, want %q", tc.name, tc.ap, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%s: %s: unexpected error: %s", tc.name, tc.ap, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%s: %s: unexpected write: got %s, want %s", tc.name, tc.ap, got, tc.b)
+		}
+	}
+}
@@ -49,3 +49,46 @@ func WriteIPv6(w io.Writer, addr net.IP) error {
 	}
 	return write(w, ip16)
 }
+
+// ErrInvalidMAC is the error thrown when an invalid hardware address is
+// specified.
+var ErrInvalidMAC = errors.New("invalid hardware address")
+
+// ReadMAC48 reads 6 bytes from r and returns them as an EUI-48 hardware
+// address, such as an Ethernet MAC address.
+func ReadMAC48(r io.Reader) (net.HardwareAddr, error) {
+	b, err := readN(r, 6)
+	if err != nil {
+		return nil, err
+	}
+	return net.HardwareAddr(b), nil
+}
+
+// WriteMAC48 writes 6 bytes to w that represents the EUI-48 hardware address
+// addr. ErrInvalidMAC is returned if addr is not 6 bytes long.
+func WriteMAC48(w io.Writer, addr net.HardwareAddr) error {
+	if len(addr) != 6 {
+		return ErrInvalidMAC
+	}
+	return write(w, addr)
+}
+
+// ReadEUI64 reads 8 bytes from r and returns them as an EUI-64 hardware
+// address.
+func ReadEUI64(r io.Reader) (net.HardwareAddr, error) {
+	b, err := readN(r, 8)
+	if err != nil {
+		return nil, err
+	}
+	return net.HardwareAddr(b), nil
+}
+
+// WriteEUI64 writes 8 bytes to w that represents the EUI-64 hardware address
+// addr. ErrInvalidMAC is returned if addr is not 8 bytes long. An EUI-48
+// address is not converted automatically.
+func WriteEUI64(w io.Writer, addr net.HardwareAddr) error {
+	if len(addr) != 8 {
+		return ErrInvalidMAC
+	}
+	return write(w, addr)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio
+
+import "net"
+
+// AppendIPv4 appends 4 bytes that represent the IPv4 address addr to dst and
+// returns the extended buffer. ErrInvalidIP is returned if addr is not an IPv4
+// address.
+func AppendIPv4(dst []byte, addr net.IP) ([]byte, error) {
+	ip4 := addr.To4()
+	if ip4 == nil {
+		return dst, ErrInvalidIP
+	}
+	return append(dst, ip4...), nil
+}
+
+// DecodeIPv4 decodes the first 4 bytes of b as an IPv4 address and returns it
+// along with the rest of b. The returned address shares the memory with b, so
+// it should be copied if b is reused.
+func DecodeIPv4(b []byte) (net.IP, []byte, error) {
+	h, rest, err := decodeN(b, net.IPv4len)
+	if err != nil {
+		return nil, b, err
+	}
+	return net.IP(h), rest, nil
+}
+
+// AppendIPv6 appends 16 bytes that represent the IPv6 address addr to dst and
+// returns the extended buffer. The addr can be an IPv4 address, and it will be
+// appended as an IPv4-mapped IPv6. ErrInvalidIP is returned if addr is not a
+// valid IP address.
+func AppendIPv6(dst []byte, addr net.IP) ([]byte, error) {
+	ip16 := addr.To16()
+	if ip16 == nil {
+		return dst, ErrInvalidIP
+	}
+	return append(dst, ip16...), nil
+}
+
+// DecodeIPv6 decodes the first 16 bytes of b as an IPv6 address and returns it
+// along with the rest of b. The returned address shares the memory with b, so
+// it should be copied if b is reused.
+func DecodeIPv6(b []byte) (net.IP, []byte, error) {
+	h, rest, err := decodeN(b, net.IPv6len)
+	if err != nil {
+		return nil, b, err
+	}
+	return net.IP(h), rest, nil
+}
+
+// AppendMAC48 appends 6 bytes that represent the EUI-48 hardware address addr
+// to dst and returns the extended buffer. ErrInvalidMAC is returned if addr is
+// not 6 bytes long.
+func AppendMAC48(dst []byte, addr net.HardwareAddr) ([]byte, error) {
+	if len(addr) != 6 {
+		return dst, ErrInvalidMAC
+	}
+	return append(dst, addr...), nil
+}
+
+// DecodeMAC48 decodes the first 6 bytes of b as an EUI-48 hardware address and
+// returns it along with the rest of b. The returned address shares the memory
+// with b, so it should be copied if b is reused.
+func DecodeMAC48(b []byte) (net.HardwareAddr, []byte, error) {
+	h, rest, err := decodeN(b, 6)
+	if err != nil {
+		return nil, b, err
+	}
+	return net.HardwareAddr(h), rest, nil
+}
+
+// AppendEUI64 appends 8 bytes that represent the EUI-64 hardware address addr
+// to dst and returns the extended buffer. ErrInvalidMAC is returned if addr is
+// not 8 bytes long.
+func AppendEUI64(dst []byte, addr net.HardwareAddr) ([]byte, error) {
+	if len(addr) != 8 {
+		return dst, ErrInvalidMAC
+	}
+	return append(dst, addr...), nil
+}
+
+// DecodeEUI64 decodes the first 8 bytes of b as an EUI-64 hardware address and
+// returns it along with the rest of b. The returned address shares the memory
+// with b, so it should be copied if b is reused.
+func DecodeEUI64(b []byte) (net.HardwareAddr, []byte, error) {
+	h, rest, err := decodeN(b, 8)
+	if err != nil {
+		return nil, b, err
+	}
+	return net.HardwareAddr(h), rest, nil
+}
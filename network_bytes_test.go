@@ -0,0 +1,42 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func TestAppendDecodeNetwork(t *testing.T) {
+	testAppendDecode(t, "IPv4", net.IP{192, 0, 2, 1}, "c0000201", typeio.AppendIPv4, typeio.DecodeIPv4)
+	testAppendDecode(t, "IPv6", net.ParseIP("2001:db8::1"), "20010db8000000000000000000000001", typeio.AppendIPv6, typeio.DecodeIPv6)
+	testAppendDecode(t, "MAC48", net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x53, 0x01}, "00005e005301", typeio.AppendMAC48, typeio.DecodeMAC48)
+	testAppendDecode(t, "EUI64", net.HardwareAddr{0x02, 0x00, 0x5e, 0x10, 0x00, 0x00, 0x00, 0x01}, "02005e1000000001", typeio.AppendEUI64, typeio.DecodeEUI64)
+
+	b, _, _ := typeio.DecodeIPv4([]byte{192, 0, 2, 1, 0xff})
+	if b = append(b, 0); len(b) != 5 || !bytes.Equal(b[:4], []byte{192, 0, 2, 1}) {
+		t.Errorf("unexpected address after append: %v", b)
+	}
+}
+
+func TestAppendNetwork_error(t *testing.T) {
+	dst := []byte{1}
+	if b, err := typeio.AppendIPv4(dst, net.ParseIP("2001:db8::1")); !errors.Is(err, typeio.ErrInvalidIP) || !bytes.Equal(b, dst) {
+		t.Errorf("unexpected result: %x, %v", b, err)
+	}
+	if b, err := typeio.AppendIPv6(dst, net.IP{1, 2, 3}); !errors.Is(err, typeio.ErrInvalidIP) || !bytes.Equal(b, dst) {
+		t.Errorf("unexpected result: %x, %v", b, err)
+	}
+	if b, err := typeio.AppendMAC48(dst, make(net.HardwareAddr, 8)); !errors.Is(err, typeio.ErrInvalidMAC) || !bytes.Equal(b, dst) {
+		t.Errorf("unexpected result: %x, %v", b, err)
+	}
+	if b, err := typeio.AppendEUI64(dst, make(net.HardwareAddr, 6)); !errors.Is(err, typeio.ErrInvalidMAC) || !bytes.Equal(b, dst) {
+		t.Errorf("unexpected result: %x, %v", b, err)
+	}
+}
@@ -94,3 +94,23 @@ func ExampleWriteIPv6() {
 	// Output:
 	// 20010db800000000000000001234567800000000000000000000ffffc0000280
 }
+
+func ExampleReadMAC48() {
+	b, _ := hex.DecodeString("ffffffffffff005e005301ff")
+	r := bytes.NewReader(b)
+
+	for {
+		v, err := typeio.ReadMAC48(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			panic(err)
+		}
+		fmt.Println(v)
+	}
+
+	// Output:
+	// ff:ff:ff:ff:ff:ff
+	// 00:5e:00:53:01:ff
+}
@@ -171,3 +171,123 @@ func TestWriteIPv6(t *testing.T) {
 		}
 	}
 }
+
+func TestReadMAC48(t *testing.T) {
+	tcs := []struct {
+		b, a string
+		e    error
+	}{
+		{"000000000000", "00:00:00:00:00:00", nil},
+		{"005e005301ff", "00:5e:00:53:01:ff", nil},
+		{"ffffffffffff", "ff:ff:ff:ff:ff:ff", nil},
+		{"", "", io.EOF},
+		{"00", "", io.ErrUnexpectedEOF},
+		{"005e005301", "", io.ErrUnexpectedEOF},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		r := bytes.NewReader(b)
+		got, err := typeio.ReadMAC48(r)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%q: error expected: got %s", tc.b, got)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%q: unexpected type of error: got %q, want %q", tc.b, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%q: unexpected error: %s", tc.b, err)
+		case tc.e == nil && got.String() != tc.a:
+			t.Errorf("%q: unexpected read: got %s, want %s", tc.b, got, tc.a)
+		}
+	}
+}
+
+func TestWriteMAC48(t *testing.T) {
+	tcs := []struct {
+		a net.HardwareAddr
+		b string
+		e error
+	}{
+		{net.HardwareAddr{0, 0, 0, 0, 0, 0}, "000000000000", nil},
+		{net.HardwareAddr{0x00, 0x5e, 0x00, 0x53, 0x01, 0xff}, "005e005301ff", nil},
+		{net.HardwareAddr{0x02, 0x00, 0x5e, 0xff, 0xfe, 0x00, 0x53, 0x01}, "", typeio.ErrInvalidMAC},
+		{net.HardwareAddr{0x00, 0x5e, 0x00, 0x53, 0x01}, "", typeio.ErrInvalidMAC},
+		{nil, "", typeio.ErrInvalidMAC},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		err := typeio.WriteMAC48(w, tc.a)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%s: error expected.", tc.a)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%s: unexpected type of error: got %q, want %q", tc.a, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%s: unexpected error: %s", tc.a, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%s: unexpected write: got %s, want %s", tc.a, got, tc.b)
+		}
+	}
+}
+
+func TestReadEUI64(t *testing.T) {
+	tcs := []struct {
+		b, a string
+		e    error
+	}{
+		{"0000000000000000", "00:00:00:00:00:00:00:00", nil},
+		{"02005efffe005301", "02:00:5e:ff:fe:00:53:01", nil},
+		{"", "", io.EOF},
+		{"02005efffe0053", "", io.ErrUnexpectedEOF},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		r := bytes.NewReader(b)
+		got, err := typeio.ReadEUI64(r)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%q: error expected: got %s", tc.b, got)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%q: unexpected type of error: got %q, want %q", tc.b, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%q: unexpected error: %s", tc.b, err)
+		case tc.e == nil && got.String() != tc.a:
+			t.Errorf("%q: unexpected read: got %s, want %s", tc.b, got, tc.a)
+		}
+	}
+}
+
+func TestWriteEUI64(t *testing.T) {
+	tcs := []struct {
+		a net.HardwareAddr
+		b string
+		e error
+	}{
+		{net.HardwareAddr{0x02, 0x00, 0x5e, 0xff, 0xfe, 0x00, 0x53, 0x01}, "02005efffe005301", nil},
+		{net.HardwareAddr{0x00, 0x5e, 0x00, 0x53, 0x01, 0xff}, "", typeio.ErrInvalidMAC},
+		{nil, "", typeio.ErrInvalidMAC},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		err := typeio.WriteEUI64(w, tc.a)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%s: error expected.", tc.a)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%s: unexpected type of error: got %q, want %q", tc.a, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%s: unexpected error: %s", tc.a, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%s: unexpected write: got %s, want %s", tc.a, got, tc.b)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"net"
+	"time"
+)
+
+// Reader wraps an io.Reader and reads values of primitive data types from it in
+// the configured byte order.
+//
+// Unlike the Read* functions, the methods of Reader do not return an error.
+// Instead, the first error encountered is recorded in the Reader, and all
+// subsequent calls do nothing and return zero values. This allows reading a
+// whole record with a series of method calls and checking the error only once
+// at the end with Err. As with the Read* functions, io.EOF is recorded only if
+// no bytes were available at all for a value, otherwise an error wrapping
+// io.ErrUnexpectedEOF is recorded.
+type Reader struct {
+	r     io.Reader
+	order binary.ByteOrder
+	err   error
+	buf   [8]byte
+}
+
+// NewReader returns a new Reader reading from r in the byte order order. If
+// order is nil, binary.BigEndian is used.
+func NewReader(r io.Reader, order binary.ByteOrder) *Reader {
+	if order == nil {
+		order = binary.BigEndian
+	}
+	return &Reader{r: r, order: order}
+}
+
+// ByteOrder returns the byte order currently used by r.
+func (r *Reader) ByteOrder() binary.ByteOrder {
+	return r.order
+}
+
+// SetByteOrder changes the byte order used by r for subsequent reads. If order
+// is nil, binary.BigEndian is used.
+func (r *Reader) SetByteOrder(order binary.ByteOrder) {
+	if order == nil {
+		order = binary.BigEndian
+	}
+	r.order = order
+}
+
+// Err returns the first error encountered by r, or nil if no error has
+// occurred.
+func (r *Reader) Err() error {
+	return r.err
+}
+
+// read reads exactly n bytes into the internal buffer and returns it. It
+// returns nil if an error occurred either previously or during this read.
+func (r *Reader) read(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	b := r.buf[:n]
+	if err := readFull(r.r, b); err != nil {
+		r.err = err
+		return nil
+	}
+	return b
+}
+
+// Uint8 reads 1 byte and returns it as a uint8 value.
+func (r *Reader) Uint8() uint8 {
+	b := r.read(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+// Int8 reads 1 byte and returns it as an int8 value.
+func (r *Reader) Int8() int8 {
+	return int8(r.Uint8())
+}
+
+// Uint16 reads 2 bytes and returns them as a uint16 value.
+func (r *Reader) Uint16() uint16 {
+	b := r.read(2)
+	if b == nil {
+		return 0
+	}
+	return r.order.Uint16(b)
+}
+
+// Int16 reads 2 bytes and returns them as an int16 value.
+func (r *Reader) Int16() int16 {
+	return int16(r.Uint16())
+}
+
+// Uint32 reads 4 bytes and returns them as a uint32 value.
+func (r *Reader) Uint32() uint32 {
+	b := r.read(4)
+	if b == nil {
+		return 0
+	}
+	return r.order.Uint32(b)
+}
+
+// Int32 reads 4 bytes and returns them as an int32 value.
+func (r *Reader) Int32() int32 {
+	return int32(r.Uint32())
+}
+
+// Uint64 reads 8 bytes and returns them as a uint64 value.
+func (r *Reader) Uint64() uint64 {
+	b := r.read(8)
+	if b == nil {
+		return 0
+	}
+	return r.order.Uint64(b)
+}
+
+// Int64 reads 8 bytes and returns them as an int64 value.
+func (r *Reader) Int64() int64 {
+	return int64(r.Uint64())
+}
+
+// Float32 reads 4 bytes and returns them as an IEEE 754 float32 value.
+func (r *Reader) Float32() float32 {
+	return math.Float32frombits(r.Uint32())
+}
+
+// Float64 reads 8 bytes and returns them as an IEEE 754 float64 value.
+func (r *Reader) Float64() float64 {
+	return math.Float64frombits(r.Uint64())
+}
+
+// UnixTime32 reads 4 bytes, interprets them as a 32-bit unsigned UNIX time,
+// and returns the UTC time it represents. It returns the zero time.Time on
+// error. See ReadUnixTimeUTC32BE for the details of the format.
+func (r *Reader) UnixTime32() time.Time {
+	b := r.read(4)
+	if b == nil {
+		return time.Time{}
+	}
+	return time.Unix(int64(r.order.Uint32(b)), 0).UTC()
+}
+
+// IPv4 reads 4 bytes and returns them as an IPv4 address. It returns nil on
+// error.
+func (r *Reader) IPv4() net.IP {
+	if r.err != nil {
+		return nil
+	}
+	ip, err := ReadIPv4(r.r)
+	if err != nil {
+		r.err = err
+		return nil
+	}
+	return ip
+}
+
+// IPv6 reads 16 bytes and returns them as an IPv6 address. It returns nil on
+// error.
+func (r *Reader) IPv6() net.IP {
+	if r.err != nil {
+		return nil
+	}
+	ip, err := ReadIPv6(r.r)
+	if err != nil {
+		r.err = err
+		return nil
+	}
+	return ip
+}
+
+// StringN reads exactly n bytes and returns them as a string with the first
+// pad and the rest removed. See ReadStringN for details.
+func (r *Reader) StringN(n int, pad byte) string {
+	if r.err != nil {
+		return ""
+	}
+	s, err := ReadStringN(r.r, n, pad)
+	if err != nil {
+		r.err = err
+		return ""
+	}
+	return s
+}
+
+// CString reads bytes until a null character or the end of input, and returns
+// the part before the null character as a string. See ReadCString for details.
+func (r *Reader) CString() string {
+	if r.err != nil {
+		return ""
+	}
+	s, _, err := ReadCString(r.r)
+	if err != nil {
+		r.err = err
+		return ""
+	}
+	return s
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func ExampleReader() {
+	b, _ := hex.DecodeString("0102d20400004e61bc0000000000c00002014142434400")
+	r := typeio.NewReader(bytes.NewReader(b), binary.LittleEndian)
+
+	major := r.Uint8()
+	minor := r.Uint8()
+	id := r.Uint32()
+	size := r.Uint64()
+	addr := r.IPv4()
+	name := r.CString()
+	if err := r.Err(); err != nil {
+		panic(err)
+	}
+	fmt.Println(major, minor, id, size, addr, name)
+
+	r.Uint8()
+	fmt.Println(r.Err())
+
+	// Output:
+	// 1 2 1234 12345678 192.0.2.1 ABCD
+	// EOF
+}
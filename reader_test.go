@@ -0,0 +1,91 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func TestReader(t *testing.T) {
+	b, _ := hex.DecodeString("" +
+		"ff" + "80" + "1234" + "fffe" + "12345678" + "fffffffe" +
+		"0102030405060708" + "fffffffffffffffe" +
+		"40490fdb" + "400921fb54442d18" +
+		"4fb98412" + "c0000201" + "20010db8000000000000000012345678" +
+		"414243202020" + "48656c6c6f00",
+	)
+	tcs := []struct {
+		order binary.ByteOrder
+		get   func(*typeio.Reader) interface{}
+		want  interface{}
+	}{
+		{binary.BigEndian, func(r *typeio.Reader) interface{} { return r.Uint8() }, uint8(0xff)},
+		{binary.BigEndian, func(r *typeio.Reader) interface{} { return r.Int8() }, int8(-128)},
+		{binary.BigEndian, func(r *typeio.Reader) interface{} { return r.Uint16() }, uint16(0x1234)},
+		{binary.LittleEndian, func(r *typeio.Reader) interface{} { return r.Int16() }, int16(-257)},
+		{binary.BigEndian, func(r *typeio.Reader) interface{} { return r.Uint32() }, uint32(0x12345678)},
+		{binary.BigEndian, func(r *typeio.Reader) interface{} { return r.Int32() }, int32(-2)},
+		{binary.LittleEndian, func(r *typeio.Reader) interface{} { return r.Uint64() }, uint64(0x0807060504030201)},
+		{binary.BigEndian, func(r *typeio.Reader) interface{} { return r.Int64() }, int64(-2)},
+		{binary.BigEndian, func(r *typeio.Reader) interface{} { return r.Float32() }, float32(3.1415927)},
+		{binary.BigEndian, func(r *typeio.Reader) interface{} { return r.Float64() }, 3.141592653589793},
+		{
+			binary.BigEndian,
+			func(r *typeio.Reader) interface{} { return r.UnixTime32() },
+			time.Date(2012, 5, 20, 23, 53, 54, 0, time.UTC),
+		},
+		{binary.BigEndian, func(r *typeio.Reader) interface{} { return r.IPv4().String() }, "192.0.2.1"},
+		{binary.BigEndian, func(r *typeio.Reader) interface{} { return r.IPv6().String() }, "2001:db8::1234:5678"},
+		{binary.BigEndian, func(r *typeio.Reader) interface{} { return r.StringN(6, ' ') }, "ABC"},
+		{binary.BigEndian, func(r *typeio.Reader) interface{} { return r.CString() }, "Hello"},
+	}
+	r := typeio.NewReader(bytes.NewReader(b), nil)
+	for i, tc := range tcs {
+		r.SetByteOrder(tc.order)
+		if got := tc.get(r); got != tc.want {
+			t.Errorf("#%d: unexpected read: got %v, want %v", i, got, tc.want)
+		}
+		if err := r.Err(); err != nil {
+			t.Fatalf("#%d: unexpected error: %s", i, err)
+		}
+	}
+	if got := r.Uint8(); got != 0 {
+		t.Errorf("unexpected read at the end: got %d", got)
+	}
+	if err := r.Err(); !errors.Is(err, io.EOF) {
+		t.Errorf("unexpected error at the end: got %v, want %v", err, io.EOF)
+	}
+}
+
+func TestReader_stickyError(t *testing.T) {
+	b, _ := hex.DecodeString("123456")
+	r := typeio.NewReader(bytes.NewReader(b), binary.LittleEndian)
+	if got := r.Uint16(); got != 0x3412 {
+		t.Errorf("unexpected read: got %04x, want 3412", got)
+	}
+	if got := r.Uint32(); got != 0 {
+		t.Errorf("unexpected read: got %08x, want 0", got)
+	}
+	if err := r.Err(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("unexpected error: got %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	if got := r.IPv4(); got != nil {
+		t.Errorf("unexpected read after error: got %s", got)
+	}
+	if got := r.CString(); got != "" {
+		t.Errorf("unexpected read after error: got %q", got)
+	}
+	if err := r.Err(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("error not sticky: got %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
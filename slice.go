@@ -0,0 +1,340 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio
+
+import (
+	"encoding/binary"
+	"io"
+	"unsafe"
+)
+
+// The functions in this file read and write slices of numbers in bulk. They
+// process the values in chunks of up to bulkBufSize bytes instead of calling
+// r.Read or w.Write for each value. If the byte order matches that of the host,
+// the memory of the slice is read into or written directly without any
+// conversion or copy. As with the other functions, the Read functions return
+// io.EOF only if no bytes were read, and if r returns an error in the middle,
+// the contents of s are undefined.
+
+// bulkBufSize is the maximum size of the buffer used to convert the byte order
+// of a slice.
+const bulkBufSize = 32 * 1024
+
+// hostOrder is the byte order of the host.
+var hostOrder = func() binary.ByteOrder {
+	v := uint16(1)
+	if *(*byte)(unsafe.Pointer(&v)) == 1 { //nolint:gosec // detecting host byte order
+		return binary.LittleEndian
+	}
+	return binary.BigEndian
+}()
+
+// ReadUint16sBE reads len(s) uint16 values in big-endian byte order from r into
+// s, 2 bytes each.
+func ReadUint16sBE(r io.Reader, s []uint16) error {
+	return readSlice(r, s, binary.BigEndian)
+}
+
+// WriteUint16sBE writes all the uint16 values in s to w in big-endian byte
+// order.
+func WriteUint16sBE(w io.Writer, s []uint16) error {
+	return writeSlice(w, s, binary.BigEndian)
+}
+
+// ReadUint16sLE reads len(s) uint16 values in little-endian byte order from r
+// into s, 2 bytes each.
+func ReadUint16sLE(r io.Reader, s []uint16) error {
+	return readSlice(r, s, binary.LittleEndian)
+}
+
+// WriteUint16sLE writes all the uint16 values in s to w in little-endian byte
+// order.
+func WriteUint16sLE(w io.Writer, s []uint16) error {
+	return writeSlice(w, s, binary.LittleEndian)
+}
+
+// ReadInt16sBE reads len(s) int16 values in big-endian byte order from r into
+// s, 2 bytes each.
+func ReadInt16sBE(r io.Reader, s []int16) error {
+	return readSlice(r, s, binary.BigEndian)
+}
+
+// WriteInt16sBE writes all the int16 values in s to w in big-endian byte order.
+func WriteInt16sBE(w io.Writer, s []int16) error {
+	return writeSlice(w, s, binary.BigEndian)
+}
+
+// ReadInt16sLE reads len(s) int16 values in little-endian byte order from r
+// into s, 2 bytes each.
+func ReadInt16sLE(r io.Reader, s []int16) error {
+	return readSlice(r, s, binary.LittleEndian)
+}
+
+// WriteInt16sLE writes all the int16 values in s to w in little-endian byte
+// order.
+func WriteInt16sLE(w io.Writer, s []int16) error {
+	return writeSlice(w, s, binary.LittleEndian)
+}
+
+// ReadUint32sBE reads len(s) uint32 values in big-endian byte order from r into
+// s, 4 bytes each.
+func ReadUint32sBE(r io.Reader, s []uint32) error {
+	return readSlice(r, s, binary.BigEndian)
+}
+
+// WriteUint32sBE writes all the uint32 values in s to w in big-endian byte
+// order.
+func WriteUint32sBE(w io.Writer, s []uint32) error {
+	return writeSlice(w, s, binary.BigEndian)
+}
+
+// ReadUint32sLE reads len(s) uint32 values in little-endian byte order from r
+// into s, 4 bytes each.
+func ReadUint32sLE(r io.Reader, s []uint32) error {
+	return readSlice(r, s, binary.LittleEndian)
+}
+
+// WriteUint32sLE writes all the uint32 values in s to w in little-endian byte
+// order.
+func WriteUint32sLE(w io.Writer, s []uint32) error {
+	return writeSlice(w, s, binary.LittleEndian)
+}
+
+// ReadInt32sBE reads len(s) int32 values in big-endian byte order from r into
+// s, 4 bytes each.
+func ReadInt32sBE(r io.Reader, s []int32) error {
+	return readSlice(r, s, binary.BigEndian)
+}
+
+// WriteInt32sBE writes all the int32 values in s to w in big-endian byte order.
+func WriteInt32sBE(w io.Writer, s []int32) error {
+	return writeSlice(w, s, binary.BigEndian)
+}
+
+// ReadInt32sLE reads len(s) int32 values in little-endian byte order from r
+// into s, 4 bytes each.
+func ReadInt32sLE(r io.Reader, s []int32) error {
+	return readSlice(r, s, binary.LittleEndian)
+}
+
+// WriteInt32sLE writes all the int32 values in s to w in little-endian byte
+// order.
+func WriteInt32sLE(w io.Writer, s []int32) error {
+	return writeSlice(w, s, binary.LittleEndian)
+}
+
+// ReadUint64sBE reads len(s) uint64 values in big-endian byte order from r into
+// s, 8 bytes each.
+func ReadUint64sBE(r io.Reader, s []uint64) error {
+	return readSlice(r, s, binary.BigEndian)
+}
+
+// WriteUint64sBE writes all the uint64 values in s to w in big-endian byte
+// order.
+func WriteUint64sBE(w io.Writer, s []uint64) error {
+	return writeSlice(w, s, binary.BigEndian)
+}
+
+// ReadUint64sLE reads len(s) uint64 values in little-endian byte order from r
+// into s, 8 bytes each.
+func ReadUint64sLE(r io.Reader, s []uint64) error {
+	return readSlice(r, s, binary.LittleEndian)
+}
+
+// WriteUint64sLE writes all the uint64 values in s to w in little-endian byte
+// order.
+func WriteUint64sLE(w io.Writer, s []uint64) error {
+	return writeSlice(w, s, binary.LittleEndian)
+}
+
+// ReadInt64sBE reads len(s) int64 values in big-endian byte order from r into
+// s, 8 bytes each.
+func ReadInt64sBE(r io.Reader, s []int64) error {
+	return readSlice(r, s, binary.BigEndian)
+}
+
+// WriteInt64sBE writes all the int64 values in s to w in big-endian byte order.
+func WriteInt64sBE(w io.Writer, s []int64) error {
+	return writeSlice(w, s, binary.BigEndian)
+}
+
+// ReadInt64sLE reads len(s) int64 values in little-endian byte order from r
+// into s, 8 bytes each.
+func ReadInt64sLE(r io.Reader, s []int64) error {
+	return readSlice(r, s, binary.LittleEndian)
+}
+
+// WriteInt64sLE writes all the int64 values in s to w in little-endian byte
+// order.
+func WriteInt64sLE(w io.Writer, s []int64) error {
+	return writeSlice(w, s, binary.LittleEndian)
+}
+
+// ReadFloat32sBE reads len(s) IEEE 754 float32 values in big-endian byte order
+// from r into s, 4 bytes each.
+func ReadFloat32sBE(r io.Reader, s []float32) error {
+	return readSlice(r, s, binary.BigEndian)
+}
+
+// WriteFloat32sBE writes all the IEEE 754 float32 values in s to w in
+// big-endian byte order.
+func WriteFloat32sBE(w io.Writer, s []float32) error {
+	return writeSlice(w, s, binary.BigEndian)
+}
+
+// ReadFloat32sLE reads len(s) IEEE 754 float32 values in little-endian byte
+// order from r into s, 4 bytes each.
+func ReadFloat32sLE(r io.Reader, s []float32) error {
+	return readSlice(r, s, binary.LittleEndian)
+}
+
+// WriteFloat32sLE writes all the IEEE 754 float32 values in s to w in
+// little-endian byte order.
+func WriteFloat32sLE(w io.Writer, s []float32) error {
+	return writeSlice(w, s, binary.LittleEndian)
+}
+
+// ReadFloat64sBE reads len(s) IEEE 754 float64 values in big-endian byte order
+// from r into s, 8 bytes each.
+func ReadFloat64sBE(r io.Reader, s []float64) error {
+	return readSlice(r, s, binary.BigEndian)
+}
+
+// WriteFloat64sBE writes all the IEEE 754 float64 values in s to w in
+// big-endian byte order.
+func WriteFloat64sBE(w io.Writer, s []float64) error {
+	return writeSlice(w, s, binary.BigEndian)
+}
+
+// ReadFloat64sLE reads len(s) IEEE 754 float64 values in little-endian byte
+// order from r into s, 8 bytes each.
+func ReadFloat64sLE(r io.Reader, s []float64) error {
+	return readSlice(r, s, binary.LittleEndian)
+}
+
+// WriteFloat64sLE writes all the IEEE 754 float64 values in s to w in
+// little-endian byte order.
+func WriteFloat64sLE(w io.Writer, s []float64) error {
+	return writeSlice(w, s, binary.LittleEndian)
+}
+
+// bulkBufLen returns the length of the buffer used to convert n values of size
+// bytes each, which is a multiple of size.
+func bulkBufLen(n, size int) int {
+	if bulkBufSize/size < n {
+		return bulkBufSize / size * size
+	}
+	return n * size
+}
+
+// bytesOf returns the memory of s as a byte slice without copying.
+func bytesOf[T Number](s []T) []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(&s[0])), len(s)*sizeOf[T]()) //nolint:gosec // zero-copy view of numbers
+}
+
+// isStdOrder returns whether order is either binary.BigEndian or
+// binary.LittleEndian, which is known to be either the same as or the opposite
+// of the host byte order.
+func isStdOrder(order binary.ByteOrder) bool {
+	return order == binary.BigEndian || order == binary.LittleEndian
+}
+
+// swapBytes reverses the byte order of each size-byte value in b in place.
+func swapBytes(b []byte, size int) {
+	switch size {
+	case 2:
+		for i := 0; i < len(b); i += 2 {
+			binary.BigEndian.PutUint16(b[i:], binary.LittleEndian.Uint16(b[i:]))
+		}
+	case 4:
+		for i := 0; i < len(b); i += 4 {
+			binary.BigEndian.PutUint32(b[i:], binary.LittleEndian.Uint32(b[i:]))
+		}
+	case 8:
+		for i := 0; i < len(b); i += 8 {
+			binary.BigEndian.PutUint64(b[i:], binary.LittleEndian.Uint64(b[i:]))
+		}
+	}
+}
+
+// readSlice reads len(s) values of type T from r into s in the byte order
+// order. If order is nil, binary.BigEndian is used.
+func readSlice[T Number](r io.Reader, s []T, order binary.ByteOrder) error {
+	if len(s) == 0 {
+		return nil
+	}
+	if order == nil {
+		order = binary.BigEndian
+	}
+	size := sizeOf[T]()
+	switch {
+	case size == 1 || order == hostOrder:
+		return readFull(r, bytesOf(s))
+	case isStdOrder(order):
+		// the opposite of the host, swapped in place after reading
+		b := bytesOf(s)
+		if err := readFull(r, b); err != nil {
+			return err
+		}
+		swapBytes(b, size)
+		return nil
+	}
+	buf := make([]byte, bulkBufLen(len(s), size))
+	for i := 0; i < len(s); {
+		n := len(buf) / size
+		if len(s)-i < n {
+			n = len(s) - i
+		}
+		b := buf[:n*size]
+		if err := readFull(r, b); err != nil {
+			if i != 0 {
+				err = noEOF(err)
+			}
+			return err
+		}
+		for j := 0; j < n; j++ {
+			s[i+j] = decodeNumber[T](b[j*size:(j+1)*size], order)
+		}
+		i += n
+	}
+	return nil
+}
+
+// writeSlice writes all the values of type T in s to w in the byte order order.
+// If order is nil, binary.BigEndian is used.
+func writeSlice[T Number](w io.Writer, s []T, order binary.ByteOrder) error {
+	if len(s) == 0 {
+		return nil
+	}
+	if order == nil {
+		order = binary.BigEndian
+	}
+	size := sizeOf[T]()
+	src := bytesOf(s)
+	if size == 1 || order == hostOrder {
+		return write(w, src)
+	}
+	buf := make([]byte, bulkBufLen(len(s), size))
+	for i := 0; i < len(s); {
+		n := len(buf) / size
+		if len(s)-i < n {
+			n = len(s) - i
+		}
+		b := buf[:n*size]
+		if isStdOrder(order) {
+			copy(b, src[i*size:])
+			swapBytes(b, size)
+		} else {
+			for j := 0; j < n; j++ {
+				encodeNumber(b[j*size:(j+1)*size], s[i+j], order)
+			}
+		}
+		if err := write(w, b); err != nil {
+			return err
+		}
+		i += n
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func ExampleReadFloat32sLE() {
+	b, _ := hex.DecodeString("0000803f000000c00000c03f")
+	r := bytes.NewReader(b)
+
+	samples := make([]float32, 3)
+	if err := typeio.ReadFloat32sLE(r, samples); err != nil {
+		panic(err)
+	}
+	fmt.Println(samples)
+
+	// Output:
+	// [1 -2 1.5]
+}
+
+func ExampleWriteUint16sBE() {
+	w := new(bytes.Buffer)
+
+	if err := typeio.WriteUint16sBE(w, []uint16{1, 2, 0xabcd}); err != nil {
+		panic(err)
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	// Output:
+	// 00010002abcd
+}
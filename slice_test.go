@@ -0,0 +1,343 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math"
+	"math/bits"
+	"reflect"
+	"testing"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func TestReadSlices(t *testing.T) {
+	b, _ := hex.DecodeString("0102030405060708f1f2f3f4f5f6f7f8")
+	rd := func() io.Reader { return bytes.NewReader(b) }
+	tcs := []struct {
+		name string
+		read func(io.Reader) (interface{}, error)
+		want interface{}
+	}{
+		{"Uint16sBE", func(r io.Reader) (interface{}, error) {
+			s := make([]uint16, 8)
+			return s, typeio.ReadUint16sBE(r, s)
+		}, []uint16{0x0102, 0x0304, 0x0506, 0x0708, 0xf1f2, 0xf3f4, 0xf5f6, 0xf7f8}},
+		{"Uint16sLE", func(r io.Reader) (interface{}, error) {
+			s := make([]uint16, 8)
+			return s, typeio.ReadUint16sLE(r, s)
+		}, []uint16{0x0201, 0x0403, 0x0605, 0x0807, 0xf2f1, 0xf4f3, 0xf6f5, 0xf8f7}},
+		{"Int16sBE", func(r io.Reader) (interface{}, error) {
+			s := make([]int16, 8)
+			return s, typeio.ReadInt16sBE(r, s)
+		}, []int16{0x0102, 0x0304, 0x0506, 0x0708, -0x0e0e, -0x0c0c, -0x0a0a, -0x0808}},
+		{"Int16sLE", func(r io.Reader) (interface{}, error) {
+			s := make([]int16, 8)
+			return s, typeio.ReadInt16sLE(r, s)
+		}, []int16{0x0201, 0x0403, 0x0605, 0x0807, -0x0d0f, -0x0b0d, -0x090b, -0x0709}},
+		{"Uint32sBE", func(r io.Reader) (interface{}, error) {
+			s := make([]uint32, 4)
+			return s, typeio.ReadUint32sBE(r, s)
+		}, []uint32{0x01020304, 0x05060708, 0xf1f2f3f4, 0xf5f6f7f8}},
+		{"Uint32sLE", func(r io.Reader) (interface{}, error) {
+			s := make([]uint32, 4)
+			return s, typeio.ReadUint32sLE(r, s)
+		}, []uint32{0x04030201, 0x08070605, 0xf4f3f2f1, 0xf8f7f6f5}},
+		{"Int32sBE", func(r io.Reader) (interface{}, error) {
+			s := make([]int32, 4)
+			return s, typeio.ReadInt32sBE(r, s)
+		}, []int32{0x01020304, 0x05060708, -0x0e0d0c0c, -0x0a090808}},
+		{"Int32sLE", func(r io.Reader) (interface{}, error) {
+			s := make([]int32, 4)
+			return s, typeio.ReadInt32sLE(r, s)
+		}, []int32{0x04030201, 0x08070605, -0x0b0c0d0f, -0x0708090b}},
+		{"Uint64sBE", func(r io.Reader) (interface{}, error) {
+			s := make([]uint64, 2)
+			return s, typeio.ReadUint64sBE(r, s)
+		}, []uint64{0x0102030405060708, 0xf1f2f3f4f5f6f7f8}},
+		{"Uint64sLE", func(r io.Reader) (interface{}, error) {
+			s := make([]uint64, 2)
+			return s, typeio.ReadUint64sLE(r, s)
+		}, []uint64{0x0807060504030201, 0xf8f7f6f5f4f3f2f1}},
+		{"Int64sBE", func(r io.Reader) (interface{}, error) {
+			s := make([]int64, 2)
+			return s, typeio.ReadInt64sBE(r, s)
+		}, []int64{0x0102030405060708, -0x0e0d0c0b0a090808}},
+		{"Int64sLE", func(r io.Reader) (interface{}, error) {
+			s := make([]int64, 2)
+			return s, typeio.ReadInt64sLE(r, s)
+		}, []int64{0x0807060504030201, -0x0708090a0b0c0d0f}},
+		{"Float32sBE", func(r io.Reader) (interface{}, error) {
+			s := make([]float32, 4)
+			return s, typeio.ReadFloat32sBE(r, s)
+		}, []float32{
+			math.Float32frombits(0x01020304), math.Float32frombits(0x05060708),
+			math.Float32frombits(0xf1f2f3f4), math.Float32frombits(0xf5f6f7f8),
+		}},
+		{"Float32sLE", func(r io.Reader) (interface{}, error) {
+			s := make([]float32, 4)
+			return s, typeio.ReadFloat32sLE(r, s)
+		}, []float32{
+			math.Float32frombits(0x04030201), math.Float32frombits(0x08070605),
+			math.Float32frombits(0xf4f3f2f1), math.Float32frombits(0xf8f7f6f5),
+		}},
+		{"Float64sBE", func(r io.Reader) (interface{}, error) {
+			s := make([]float64, 2)
+			return s, typeio.ReadFloat64sBE(r, s)
+		}, []float64{math.Float64frombits(0x0102030405060708), math.Float64frombits(0xf1f2f3f4f5f6f7f8)}},
+		{"Float64sLE", func(r io.Reader) (interface{}, error) {
+			s := make([]float64, 2)
+			return s, typeio.ReadFloat64sLE(r, s)
+		}, []float64{math.Float64frombits(0x0807060504030201), math.Float64frombits(0xf8f7f6f5f4f3f2f1)}},
+	}
+	for _, tc := range tcs {
+		got, err := tc.read(rd())
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tc.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: unexpected read: got %x, want %x", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestWriteSlices(t *testing.T) {
+	tcs := []struct {
+		name  string
+		write func(io.Writer) error
+		b     string
+	}{
+		{"Uint16sBE", func(w io.Writer) error { return typeio.WriteUint16sBE(w, []uint16{0x0102, 0xf1f2}) }, "0102f1f2"},
+		{"Uint16sLE", func(w io.Writer) error { return typeio.WriteUint16sLE(w, []uint16{0x0102, 0xf1f2}) }, "0201f2f1"},
+		{"Int16sBE", func(w io.Writer) error { return typeio.WriteInt16sBE(w, []int16{0x0102, -2}) }, "0102fffe"},
+		{"Int16sLE", func(w io.Writer) error { return typeio.WriteInt16sLE(w, []int16{0x0102, -2}) }, "0201feff"},
+		{"Uint32sBE", func(w io.Writer) error { return typeio.WriteUint32sBE(w, []uint32{0x01020304}) }, "01020304"},
+		{"Uint32sLE", func(w io.Writer) error { return typeio.WriteUint32sLE(w, []uint32{0x01020304}) }, "04030201"},
+		{"Int32sBE", func(w io.Writer) error { return typeio.WriteInt32sBE(w, []int32{-2}) }, "fffffffe"},
+		{"Int32sLE", func(w io.Writer) error { return typeio.WriteInt32sLE(w, []int32{-2}) }, "feffffff"},
+		{"Uint64sBE", func(w io.Writer) error { return typeio.WriteUint64sBE(w, []uint64{0x0102030405060708}) }, "0102030405060708"},
+		{"Uint64sLE", func(w io.Writer) error { return typeio.WriteUint64sLE(w, []uint64{0x0102030405060708}) }, "0807060504030201"},
+		{"Int64sBE", func(w io.Writer) error { return typeio.WriteInt64sBE(w, []int64{-2}) }, "fffffffffffffffe"},
+		{"Int64sLE", func(w io.Writer) error { return typeio.WriteInt64sLE(w, []int64{-2}) }, "feffffffffffffff"},
+		{"Float32sBE", func(w io.Writer) error { return typeio.WriteFloat32sBE(w, []float32{1, -2}) }, "3f800000c0000000"},
+		{"Float32sLE", func(w io.Writer) error { return typeio.WriteFloat32sLE(w, []float32{1, -2}) }, "0000803f000000c0"},
+		{"Float64sBE", func(w io.Writer) error { return typeio.WriteFloat64sBE(w, []float64{1}) }, "3ff0000000000000"},
+		{"Float64sLE", func(w io.Writer) error { return typeio.WriteFloat64sLE(w, []float64{1}) }, "000000000000f03f"},
+		{"empty", func(w io.Writer) error { return typeio.WriteFloat64sLE(w, nil) }, ""},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		if err := tc.write(w); err != nil {
+			t.Errorf("%s: unexpected error: %s", tc.name, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%s: unexpected write: got %s, want %s", tc.name, got, tc.b)
+		}
+	}
+}
+
+// TestSlices_large tests slices larger than the internal buffer, which are
+// converted in multiple chunks unless the byte order matches the host.
+func TestSlices_large(t *testing.T) {
+	src := make([]uint32, 50000)
+	for i := range src {
+		src[i] = uint32(i) * 0x01010101
+	}
+	for i, f := range []struct {
+		write func(io.Writer, []uint32) error
+		read  func(io.Reader, []uint32) error
+		one   func(io.Reader) (uint32, error)
+	}{
+		{typeio.WriteUint32sBE, typeio.ReadUint32sBE, typeio.ReadUint32BE},
+		{typeio.WriteUint32sLE, typeio.ReadUint32sLE, typeio.ReadUint32LE},
+	} {
+		w := new(bytes.Buffer)
+		if err := f.write(w, src); err != nil {
+			t.Fatalf("#%d: unexpected error: %s", i, err)
+		}
+		b := w.Bytes()
+		if len(b) != len(src)*4 {
+			t.Fatalf("#%d: unexpected length: got %d, want %d", i, len(b), len(src)*4)
+		}
+		r := bytes.NewReader(b)
+		for j, want := range src {
+			if v, err := f.one(r); err != nil || v != want {
+				t.Fatalf("#%d: [%d]: unexpected value: got %x, %v, want %x", i, j, v, err, want)
+			}
+		}
+
+		dst := make([]uint32, len(src))
+		if err := f.read(bytes.NewReader(b), dst); err != nil {
+			t.Fatalf("#%d: unexpected error: %s", i, err)
+		}
+		if !reflect.DeepEqual(dst, src) {
+			t.Errorf("#%d: unexpected read.", i)
+		}
+
+		if err := f.read(bytes.NewReader(nil), dst); !errors.Is(err, io.EOF) {
+			t.Errorf("#%d: unexpected error: got %v, want %v", i, err, io.EOF)
+		}
+		if err := f.read(bytes.NewReader(b[:len(b)-1]), dst); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("#%d: unexpected error: got %v, want %v", i, err, io.ErrUnexpectedEOF)
+		}
+	}
+}
+
+// otherOrder is a byte order that is not recognized as either the host byte
+// order or its opposite.
+type otherOrder struct{ binary.ByteOrder }
+
+func TestSlices_otherOrder(t *testing.T) {
+	src := make([]int64, 10000)
+	for i := range src {
+		src[i] = -int64(i) * 0x0101010101
+	}
+	w := new(bytes.Buffer)
+	if err := typeio.WriteSlice(w, src, otherOrder{binary.BigEndian}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := new(bytes.Buffer)
+	if err := typeio.WriteInt64sBE(want, src); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !bytes.Equal(w.Bytes(), want.Bytes()) {
+		t.Errorf("unexpected write.")
+	}
+	dst, err := typeio.ReadSlice[int64](bytes.NewReader(w.Bytes()), len(src), otherOrder{binary.LittleEndian})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for i := range dst {
+		if v := int64(bits.ReverseBytes64(uint64(src[i]))); dst[i] != v {
+			t.Fatalf("[%d]: unexpected read: got %x, want %x", i, dst[i], v)
+		}
+	}
+	_, err = typeio.ReadSlice[int64](bytes.NewReader(w.Bytes()[:len(w.Bytes())-1]), len(src), otherOrder{binary.LittleEndian})
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("unexpected error: got %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+const benchSamples = 1 << 16
+
+func BenchmarkReadFloat32LE(b *testing.B) {
+	data := make([]byte, benchSamples*4)
+	s := make([]float32, benchSamples)
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := bytes.NewReader(data)
+		for j := range s {
+			v, err := typeio.ReadFloat32LE(r)
+			if err != nil {
+				b.Fatal(err)
+			}
+			s[j] = v
+		}
+	}
+}
+
+func BenchmarkReadFloat32sLE(b *testing.B) {
+	data := make([]byte, benchSamples*4)
+	s := make([]float32, benchSamples)
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := typeio.ReadFloat32sLE(bytes.NewReader(data), s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadFloat32BE(b *testing.B) {
+	data := make([]byte, benchSamples*4)
+	s := make([]float32, benchSamples)
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := bytes.NewReader(data)
+		for j := range s {
+			v, err := typeio.ReadFloat32BE(r)
+			if err != nil {
+				b.Fatal(err)
+			}
+			s[j] = v
+		}
+	}
+}
+
+func BenchmarkReadFloat32sBE(b *testing.B) {
+	data := make([]byte, benchSamples*4)
+	s := make([]float32, benchSamples)
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := typeio.ReadFloat32sBE(bytes.NewReader(data), s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkWriteUint16LE(b *testing.B) {
+	s := make([]uint16, benchSamples)
+	w := bytes.NewBuffer(make([]byte, 0, benchSamples*2))
+	b.SetBytes(benchSamples * 2)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.Reset()
+		for _, v := range s {
+			if err := typeio.WriteUint16LE(w, v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkWriteUint16sLE(b *testing.B) {
+	s := make([]uint16, benchSamples)
+	w := bytes.NewBuffer(make([]byte, 0, benchSamples*2))
+	b.SetBytes(benchSamples * 2)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.Reset()
+		if err := typeio.WriteUint16sLE(w, s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkWriteUint16BE(b *testing.B) {
+	s := make([]uint16, benchSamples)
+	w := bytes.NewBuffer(make([]byte, 0, benchSamples*2))
+	b.SetBytes(benchSamples * 2)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.Reset()
+		for _, v := range s {
+			if err := typeio.WriteUint16BE(w, v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkWriteUint16sBE(b *testing.B) {
+	s := make([]uint16, benchSamples)
+	w := bytes.NewBuffer(make([]byte, 0, benchSamples*2))
+	b.SetBytes(benchSamples * 2)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.Reset()
+		if err := typeio.WriteUint16sBE(w, s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -5,12 +5,25 @@
 package typeio
 
 import (
+	"bufio"
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
+	"math"
+	"strings"
+	"unicode/utf8"
 )
 
+// ErrInvalidCString is the error thrown when a string to be written as a
+// null-terminated string contains a null character.
+var ErrInvalidCString = errors.New("string contains a null character")
+
+// ErrTooLong is the error thrown when data is longer than the specified limit
+// or the maximum length the format can represent.
+var ErrTooLong = errors.New("too long")
+
 // ReadStringN reads exactly n bytes from r and returns it as a string with the
 // first pad and the rest removed. Generally, it is expected to specify 0 (null
 // character) or ' ' (space) as pad.
@@ -25,12 +38,70 @@ func ReadStringN(r io.Reader, n int, pad byte) (string, error) {
 	return string(b), nil
 }
 
+// WriteStringN writes s to w as exactly n bytes, padding the rest with pad.
+// ErrTooLong is returned if s is longer than n bytes. Note that if s contains
+// pad, ReadStringN will not read back the part after it.
+func WriteStringN(w io.Writer, s string, n int, pad byte) error {
+	if n < 0 {
+		return fmt.Errorf("%w: %d bytes", ErrInvalidSize, n)
+	}
+	if n < len(s) {
+		return fmt.Errorf("%w: %d bytes exceeds %d", ErrTooLong, len(s), n)
+	}
+	b := make([]byte, n)
+	copy(b[copy(b, s):], bytes.Repeat([]byte{pad}, n-len(s)))
+	return write(w, b)
+}
+
+// WriteStringNTruncate is identical to WriteStringN except that s is truncated
+// instead of returning an error if it is longer than n bytes. The s is
+// truncated at a UTF-8 rune boundary, so that no partial multi-byte character
+// is written. In that case, the rest is padded with pad.
+func WriteStringNTruncate(w io.Writer, s string, n int, pad byte) error {
+	if 0 <= n && n < len(s) {
+		i := n
+		for 0 < i && !utf8.RuneStart(s[i]) {
+			i--
+		}
+		s = s[:i]
+	}
+	return WriteStringN(w, s, n, pad)
+}
+
 // ReadCString keeps reading from r until it encounters a null character
 // represented by 0x00 or io.EOF, and returns the part before the null character
 // as a string. The second return value is the total number of bytes read
 // including the null character. Be careful of overruns when using ReadCString
-// for unpredictable inputs.
+// for unpredictable inputs, ReadCStringMax is safer for such cases.
+//
+// If r is a *bufio.Reader or implements io.ByteReader, ReadCString reads from
+// it in chunks or byte by byte using those interfaces. Otherwise, r.Read is
+// called for each byte, so it is recommended to wrap an unbuffered r such as a
+// file or a network connection with bufio.Reader.
 func ReadCString(r io.Reader) (string, int, error) {
+	return readCString(r, -1)
+}
+
+// ReadCStringMax is identical to ReadCString except that it reads at most max
+// bytes of the string excluding the null character. If no null character is
+// found within the limit, it stops after reading max+1 bytes and returns the
+// first max bytes as a string along with an error wrapping ErrTooLong.
+func ReadCStringMax(r io.Reader, max int) (string, int, error) {
+	if max < 0 {
+		return "", 0, fmt.Errorf("%w: %d bytes", ErrInvalidSize, max)
+	}
+	return readCString(r, max)
+}
+
+// readCString implements ReadCString and ReadCStringMax. A negative max means
+// no limit.
+func readCString(r io.Reader, max int) (string, int, error) {
+	switch r := r.(type) {
+	case *bufio.Reader:
+		return readCStringBuffered(r, max)
+	case io.ByteReader:
+		return readCStringBytes(r, max)
+	}
 	var b []byte
 	var t int
 	buf := []byte{0}
@@ -41,17 +112,348 @@ func ReadCString(r io.Reader) (string, int, error) {
 			if buf[0] == 0 {
 				break
 			}
+			if len(b) == max {
+				return string(b), t, errCStringTooLong(max)
+			}
 			b = append(b, buf[0])
 		}
 		if err != nil {
-			if errors.Is(err, io.EOF) {
-				if 0 < t {
-					return string(b), t, nil
-				}
-				return string(b), t, io.EOF
-			}
-			return string(b), t, fmt.Errorf("read failure: %w", err)
+			return cstringEnd(b, err)
 		}
 	}
 	return string(b), t, nil
 }
+
+// readCStringBytes implements readCString for io.ByteReader.
+func readCStringBytes(r io.ByteReader, max int) (string, int, error) {
+	var b []byte
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return cstringEnd(b, err)
+		}
+		if c == 0 {
+			return string(b), len(b) + 1, nil
+		}
+		if len(b) == max {
+			return string(b), len(b) + 1, errCStringTooLong(max)
+		}
+		b = append(b, c)
+	}
+}
+
+// readCStringBuffered implements readCString for bufio.Reader. It searches
+// the buffered data for a null character rather than reading byte by byte.
+func readCStringBuffered(r *bufio.Reader, max int) (string, int, error) {
+	var b []byte
+	for {
+		if _, err := r.Peek(1); err != nil {
+			return cstringEnd(b, err)
+		}
+		p, _ := r.Peek(r.Buffered())
+		i := bytes.IndexByte(p, 0)
+		if 0 <= max {
+			if rest := max - len(b); rest < i || (i < 0 && rest < len(p)) {
+				b = append(b, p[:rest]...)
+				_, _ = r.Discard(rest + 1)
+				return string(b), len(b) + 1, errCStringTooLong(max)
+			}
+		}
+		if 0 <= i {
+			b = append(b, p[:i]...)
+			_, _ = r.Discard(i + 1)
+			return string(b), len(b) + 1, nil
+		}
+		b = append(b, p...)
+		_, _ = r.Discard(len(p))
+	}
+}
+
+// cstringEnd returns the result of readCString when err is returned before a
+// null character is found.
+func cstringEnd(b []byte, err error) (string, int, error) {
+	if errors.Is(err, io.EOF) {
+		if 0 < len(b) {
+			return string(b), len(b), nil
+		}
+		return "", 0, io.EOF
+	}
+	return string(b), len(b), fmt.Errorf("read failure: %w", err)
+}
+
+func errCStringTooLong(max int) error {
+	return fmt.Errorf("%w: no null character within %d bytes", ErrTooLong, max)
+}
+
+// WriteCString writes s followed by a null character to w. ErrInvalidCString
+// is returned if s contains a null character.
+func WriteCString(w io.Writer, s string) error {
+	if strings.IndexByte(s, 0) != -1 {
+		return ErrInvalidCString
+	}
+	b := make([]byte, len(s)+1)
+	copy(b, s)
+	return write(w, b)
+}
+
+// prefixedChunkSize is the size of the chunks in which readPrefixed reads data
+// longer than that. The buffer grows only as the data actually arrives, so a
+// huge length prefix in hostile input does not cause a huge allocation.
+const prefixedChunkSize = 64 << 10
+
+// readPrefixed reads the n bytes of data following a length prefix. max is the
+// limit of n specified by the caller, and a negative max means no limit.
+func readPrefixed(r io.Reader, n uint64, max int) ([]byte, error) {
+	if (0 <= max && uint64(max) < n) || uint64(^uint(0)>>1) < n {
+		return nil, fmt.Errorf("%w: length %d exceeds the limit %d", ErrTooLong, n, max)
+	}
+	if n <= prefixedChunkSize {
+		b, err := readN(r, int(n))
+		if err != nil {
+			return nil, noEOF(err)
+		}
+		return b, nil
+	}
+	var buf bytes.Buffer
+	buf.Grow(prefixedChunkSize)
+	if _, err := io.CopyN(&buf, r, int64(n)); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("read failure: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadBytes8 reads a length prefix of a uint8 from r, then reads and returns
+// the number of bytes specified by the prefix. ErrTooLong is returned without
+// reading the data if the length exceeds max. A negative max means no limit
+// other than the maximum value of the prefix.
+func ReadBytes8(r io.Reader, max int) ([]byte, error) {
+	n, err := ReadUint8(r)
+	if err != nil {
+		return nil, err
+	}
+	return readPrefixed(r, uint64(n), max)
+}
+
+// WriteBytes8 writes the length of v as a uint8 followed by v to w. ErrTooLong
+// is returned if the length of v exceeds 255.
+func WriteBytes8(w io.Writer, v []byte) error {
+	if math.MaxUint8 < uint64(len(v)) {
+		return fmt.Errorf("%w: length %d exceeds %d", ErrTooLong, len(v), uint64(math.MaxUint8))
+	}
+	b := make([]byte, 1, 1+len(v))
+	b[0] = uint8(len(v))
+	return write(w, append(b, v...))
+}
+
+// ReadString8 is identical to ReadBytes8 except that it returns the data as a
+// string.
+func ReadString8(r io.Reader, max int) (string, error) {
+	b, err := ReadBytes8(r, max)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// WriteString8 is identical to WriteBytes8 except that it takes the data as a
+// string.
+func WriteString8(w io.Writer, v string) error {
+	return WriteBytes8(w, []byte(v))
+}
+
+// ReadBytes16BE reads a length prefix of a uint16 in big-endian byte order from
+// r, then reads and returns the number of bytes specified by the prefix.
+// ErrTooLong is returned without reading the data if the length exceeds max. A
+// negative max means no limit other than the maximum value of the prefix.
+func ReadBytes16BE(r io.Reader, max int) ([]byte, error) {
+	n, err := ReadUint16BE(r)
+	if err != nil {
+		return nil, err
+	}
+	return readPrefixed(r, uint64(n), max)
+}
+
+// WriteBytes16BE writes the length of v as a uint16 in big-endian byte order
+// followed by v to w. ErrTooLong is returned if the length of v exceeds 65535.
+func WriteBytes16BE(w io.Writer, v []byte) error {
+	if math.MaxUint16 < uint64(len(v)) {
+		return fmt.Errorf("%w: length %d exceeds %d", ErrTooLong, len(v), uint64(math.MaxUint16))
+	}
+	b := make([]byte, 2, 2+len(v))
+	binary.BigEndian.PutUint16(b, uint16(len(v)))
+	return write(w, append(b, v...))
+}
+
+// ReadString16BE is identical to ReadBytes16BE except that it returns the data
+// as a string.
+func ReadString16BE(r io.Reader, max int) (string, error) {
+	b, err := ReadBytes16BE(r, max)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// WriteString16BE is identical to WriteBytes16BE except that it takes the data
+// as a string.
+func WriteString16BE(w io.Writer, v string) error {
+	return WriteBytes16BE(w, []byte(v))
+}
+
+// ReadBytes16LE reads a length prefix of a uint16 in little-endian byte order
+// from r, then reads and returns the number of bytes specified by the prefix.
+// ErrTooLong is returned without reading the data if the length exceeds max. A
+// negative max means no limit other than the maximum value of the prefix.
+func ReadBytes16LE(r io.Reader, max int) ([]byte, error) {
+	n, err := ReadUint16LE(r)
+	if err != nil {
+		return nil, err
+	}
+	return readPrefixed(r, uint64(n), max)
+}
+
+// WriteBytes16LE writes the length of v as a uint16 in little-endian byte order
+// followed by v to w. ErrTooLong is returned if the length of v exceeds 65535.
+func WriteBytes16LE(w io.Writer, v []byte) error {
+	if math.MaxUint16 < uint64(len(v)) {
+		return fmt.Errorf("%w: length %d exceeds %d", ErrTooLong, len(v), uint64(math.MaxUint16))
+	}
+	b := make([]byte, 2, 2+len(v))
+	binary.LittleEndian.PutUint16(b, uint16(len(v)))
+	return write(w, append(b, v...))
+}
+
+// ReadString16LE is identical to ReadBytes16LE except that it returns the data
+// as a string.
+func ReadString16LE(r io.Reader, max int) (string, error) {
+	b, err := ReadBytes16LE(r, max)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// WriteString16LE is identical to WriteBytes16LE except that it takes the data
+// as a string.
+func WriteString16LE(w io.Writer, v string) error {
+	return WriteBytes16LE(w, []byte(v))
+}
+
+// ReadBytes32BE reads a length prefix of a uint32 in big-endian byte order from
+// r, then reads and returns the number of bytes specified by the prefix.
+// ErrTooLong is returned without reading the data if the length exceeds max. A
+// negative max means no limit other than the maximum value of the prefix.
+func ReadBytes32BE(r io.Reader, max int) ([]byte, error) {
+	n, err := ReadUint32BE(r)
+	if err != nil {
+		return nil, err
+	}
+	return readPrefixed(r, uint64(n), max)
+}
+
+// WriteBytes32BE writes the length of v as a uint32 in big-endian byte order
+// followed by v to w. ErrTooLong is returned if the length of v exceeds
+// 4294967295.
+func WriteBytes32BE(w io.Writer, v []byte) error {
+	if math.MaxUint32 < uint64(len(v)) {
+		return fmt.Errorf("%w: length %d exceeds %d", ErrTooLong, len(v), uint64(math.MaxUint32))
+	}
+	b := make([]byte, 4, 4+len(v))
+	binary.BigEndian.PutUint32(b, uint32(len(v)))
+	return write(w, append(b, v...))
+}
+
+// ReadString32BE is identical to ReadBytes32BE except that it returns the data
+// as a string.
+func ReadString32BE(r io.Reader, max int) (string, error) {
+	b, err := ReadBytes32BE(r, max)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// WriteString32BE is identical to WriteBytes32BE except that it takes the data
+// as a string.
+func WriteString32BE(w io.Writer, v string) error {
+	return WriteBytes32BE(w, []byte(v))
+}
+
+// ReadBytes32LE reads a length prefix of a uint32 in little-endian byte order
+// from r, then reads and returns the number of bytes specified by the prefix.
+// ErrTooLong is returned without reading the data if the length exceeds max. A
+// negative max means no limit other than the maximum value of the prefix.
+func ReadBytes32LE(r io.Reader, max int) ([]byte, error) {
+	n, err := ReadUint32LE(r)
+	if err != nil {
+		return nil, err
+	}
+	return readPrefixed(r, uint64(n), max)
+}
+
+// WriteBytes32LE writes the length of v as a uint32 in little-endian byte order
+// followed by v to w. ErrTooLong is returned if the length of v exceeds
+// 4294967295.
+func WriteBytes32LE(w io.Writer, v []byte) error {
+	if math.MaxUint32 < uint64(len(v)) {
+		return fmt.Errorf("%w: length %d exceeds %d", ErrTooLong, len(v), uint64(math.MaxUint32))
+	}
+	b := make([]byte, 4, 4+len(v))
+	binary.LittleEndian.PutUint32(b, uint32(len(v)))
+	return write(w, append(b, v...))
+}
+
+// ReadString32LE is identical to ReadBytes32LE except that it returns the data
+// as a string.
+func ReadString32LE(r io.Reader, max int) (string, error) {
+	b, err := ReadBytes32LE(r, max)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// WriteString32LE is identical to WriteBytes32LE except that it takes the data
+// as a string.
+func WriteString32LE(w io.Writer, v string) error {
+	return WriteBytes32LE(w, []byte(v))
+}
+
+// ReadBytesUvarint reads a length prefix in unsigned LEB128 encoding, as used
+// by binary.Uvarint and Protocol Buffers, from r, then reads and returns the
+// number of bytes specified by the prefix. ErrTooLong is returned without
+// reading the data if the length exceeds max. A negative max means no limit.
+func ReadBytesUvarint(r io.Reader, max int) ([]byte, error) {
+	n, _, err := ReadULEB128(r)
+	if err != nil {
+		return nil, err
+	}
+	return readPrefixed(r, n, max)
+}
+
+// WriteBytesUvarint writes the length of v in unsigned LEB128 encoding followed
+// by v to w.
+func WriteBytesUvarint(w io.Writer, v []byte) error {
+	b := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(v))
+	b = b[:binary.PutUvarint(b, uint64(len(v)))]
+	return write(w, append(b, v...))
+}
+
+// ReadStringUvarint is identical to ReadBytesUvarint except that it returns the
+// data as a string.
+func ReadStringUvarint(r io.Reader, max int) (string, error) {
+	b, err := ReadBytesUvarint(r, max)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// WriteStringUvarint is identical to WriteBytesUvarint except that it takes the
+// data as a string.
+func WriteStringUvarint(w io.Writer, v string) error {
+	return WriteBytesUvarint(w, []byte(v))
+}
@@ -53,3 +53,109 @@ func ExampleReadCString() {
 	// 6 Hello
 	// 6 世界
 }
+
+func ExampleReadString8() {
+	b, _ := hex.DecodeString("0548656c6c6f06e4b896e7958c")
+	r := bytes.NewReader(b)
+
+	for {
+		s, err := typeio.ReadString8(r, 64)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			panic(err)
+		}
+		fmt.Println(s)
+	}
+
+	// Output:
+	// Hello
+	// 世界
+}
+
+func ExampleWriteString16BE() {
+	w := new(bytes.Buffer)
+
+	data := []string{"Hello", "世界"}
+	for _, s := range data {
+		if err := typeio.WriteString16BE(w, s); err != nil {
+			panic(err)
+		}
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	// Output:
+	// 000548656c6c6f0006e4b896e7958c
+}
+
+func ExampleReadBytesUvarint() {
+	b, _ := hex.DecodeString("03010203")
+	r := bytes.NewReader(b)
+
+	v, err := typeio.ReadBytesUvarint(r, 1024)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(v)
+
+	// Output:
+	// [1 2 3]
+}
+
+func ExampleWriteCString() {
+	w := new(bytes.Buffer)
+
+	data := []string{"Hello", "世界"}
+	for _, s := range data {
+		if err := typeio.WriteCString(w, s); err != nil {
+			panic(err)
+		}
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	// Output:
+	// 48656c6c6f00e4b896e7958c00
+}
+
+func ExampleWriteStringN() {
+	w := new(bytes.Buffer)
+
+	data := []string{"Hello", "世界"}
+	for _, s := range data {
+		if err := typeio.WriteStringN(w, s, 6, 0); err != nil {
+			panic(err)
+		}
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	// Output:
+	// 48656c6c6f00e4b896e7958c
+}
+
+func ExampleWriteStringNTruncate() {
+	w := new(bytes.Buffer)
+
+	if err := typeio.WriteStringNTruncate(w, "世界", 5, ' '); err != nil {
+		panic(err)
+	}
+	fmt.Printf("%q\n", w.String())
+
+	// Output:
+	// "世  "
+}
+
+func ExampleReadCStringMax() {
+	b, _ := hex.DecodeString("48656c6c6f00576f726c642100")
+	r := bytes.NewReader(b)
+
+	s, n, err := typeio.ReadCStringMax(r, 5)
+	fmt.Println(n, s, err)
+
+	_, _, err = typeio.ReadCStringMax(r, 5)
+	fmt.Println(err)
+
+	// Output:
+	// 6 Hello <nil>
+	// too long: no null character within 5 bytes
+}
@@ -5,12 +5,15 @@
 package typeio_test
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/tunabay/go-typeio"
 )
@@ -93,3 +96,317 @@ func TestReadCString(t *testing.T) {
 		}
 	}
 }
+
+func TestReadBytesPrefixed(t *testing.T) {
+	tcs := []struct {
+		f   func(io.Reader, int) ([]byte, error)
+		b   string
+		max int
+		v   string
+		e   error
+	}{
+		{typeio.ReadBytes8, "03414243", -1, "ABC", nil},
+		{typeio.ReadBytes8, "0341424344", 3, "ABC", nil},
+		{typeio.ReadBytes8, "00", 0, "", nil},
+		{typeio.ReadBytes8, "03414243", 2, "", typeio.ErrTooLong},
+		{typeio.ReadBytes8, "034142", -1, "", io.ErrUnexpectedEOF},
+		{typeio.ReadBytes8, "03", -1, "", io.ErrUnexpectedEOF},
+		{typeio.ReadBytes8, "", -1, "", io.EOF},
+		{typeio.ReadBytes16BE, "0003414243", -1, "ABC", nil},
+		{typeio.ReadBytes16BE, "ffff", 1024, "", typeio.ErrTooLong},
+		{typeio.ReadBytes16BE, "00", -1, "", io.ErrUnexpectedEOF},
+		{typeio.ReadBytes16LE, "0300414243", -1, "ABC", nil},
+		{typeio.ReadBytes16LE, "0003414243", -1, "", io.ErrUnexpectedEOF},
+		{typeio.ReadBytes32BE, "00000003414243", 3, "ABC", nil},
+		{typeio.ReadBytes32BE, "ffffffff", 1 << 20, "", typeio.ErrTooLong},
+		{typeio.ReadBytes32LE, "03000000414243", 3, "ABC", nil},
+		{typeio.ReadBytes32LE, "", 3, "", io.EOF},
+		{typeio.ReadBytesUvarint, "03414243", -1, "ABC", nil},
+		{typeio.ReadBytesUvarint, "8001", 127, "", typeio.ErrTooLong},
+		{typeio.ReadBytesUvarint, "80", -1, "", io.ErrUnexpectedEOF},
+		{typeio.ReadBytesUvarint, "", -1, "", io.EOF},
+		{typeio.ReadBytesUvarint, "ffffffffffffffff7f414243", -1, "", io.ErrUnexpectedEOF},
+		{typeio.ReadBytes32BE, "ffffffff414243", -1, "", io.ErrUnexpectedEOF},
+		{typeio.ReadBytes32LE, "ffffffff", -1, "", io.ErrUnexpectedEOF},
+	}
+	for i, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("#%d: invalid test data: %s", i, err)
+			continue
+		}
+		r := bytes.NewReader(b)
+		got, err := tc.f(r, tc.max)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("#%d: error expected: got %q", i, got)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("#%d: unexpected type of error: got %q, want %q", i, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("#%d: unexpected error: %s", i, err)
+		case tc.e == nil && string(got) != tc.v:
+			t.Errorf("#%d: unexpected read: got %q, want %q", i, got, tc.v)
+		}
+	}
+}
+
+func TestReadBytesPrefixed_long(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789abcdef"), 20000)
+	w := new(bytes.Buffer)
+	if err := typeio.WriteBytesUvarint(w, data); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := typeio.ReadBytesUvarint(w, -1)
+	switch {
+	case err != nil:
+		t.Errorf("unexpected error: %s", err)
+	case !bytes.Equal(got, data):
+		t.Errorf("unexpected read: got %d bytes, want %d bytes", len(got), len(data))
+	}
+}
+
+func TestWriteBytesPrefixed(t *testing.T) {
+	tcs := []struct {
+		f func(io.Writer, []byte) error
+		v []byte
+		b string
+		e error
+	}{
+		{typeio.WriteBytes8, []byte("ABC"), "03414243", nil},
+		{typeio.WriteBytes8, nil, "00", nil},
+		{typeio.WriteBytes8, make([]byte, 255), "ff" + strings.Repeat("00", 255), nil},
+		{typeio.WriteBytes8, make([]byte, 256), "", typeio.ErrTooLong},
+		{typeio.WriteBytes16BE, []byte("ABC"), "0003414243", nil},
+		{typeio.WriteBytes16BE, make([]byte, 65536), "", typeio.ErrTooLong},
+		{typeio.WriteBytes16LE, []byte("ABC"), "0300414243", nil},
+		{typeio.WriteBytes32BE, []byte("ABC"), "00000003414243", nil},
+		{typeio.WriteBytes32LE, []byte("ABC"), "03000000414243", nil},
+		{typeio.WriteBytesUvarint, []byte("ABC"), "03414243", nil},
+		{typeio.WriteBytesUvarint, make([]byte, 128), "8001" + strings.Repeat("00", 128), nil},
+	}
+	for i, tc := range tcs {
+		w := new(bytes.Buffer)
+		err := tc.f(w, tc.v)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("#%d: error expected.", i)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("#%d: unexpected type of error: got %q, want %q", i, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("#%d: unexpected error: %s", i, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("#%d: unexpected write: got %s, want %s", i, got, tc.b)
+		}
+	}
+}
+
+func TestStringPrefixed_roundTrip(t *testing.T) {
+	tcs := []struct {
+		w func(io.Writer, string) error
+		r func(io.Reader, int) (string, error)
+	}{
+		{typeio.WriteString8, typeio.ReadString8},
+		{typeio.WriteString16BE, typeio.ReadString16BE},
+		{typeio.WriteString16LE, typeio.ReadString16LE},
+		{typeio.WriteString32BE, typeio.ReadString32BE},
+		{typeio.WriteString32LE, typeio.ReadString32LE},
+		{typeio.WriteStringUvarint, typeio.ReadStringUvarint},
+	}
+	for i, tc := range tcs {
+		buf := new(bytes.Buffer)
+		for _, s := range []string{"漢字 ABC", "", "x"} {
+			if err := tc.w(buf, s); err != nil {
+				t.Fatalf("#%d: %q: unexpected error: %s", i, s, err)
+			}
+			got, err := tc.r(buf, 16)
+			if err != nil {
+				t.Fatalf("#%d: %q: unexpected error: %s", i, s, err)
+			}
+			if got != s {
+				t.Errorf("#%d: unexpected read: got %q, want %q", i, got, s)
+			}
+		}
+	}
+}
+
+func TestWriteCString(t *testing.T) {
+	tcs := []struct {
+		s string
+		b string
+		e error
+	}{
+		{"", "00", nil},
+		{"A", "4100", nil},
+		{"ABC abc ", "414243206162632000", nil},
+		{"漢字 ABC", "e6bca2e5ad972041424300", nil},
+		{"ABC\x00abc", "", typeio.ErrInvalidCString},
+		{"\x00", "", typeio.ErrInvalidCString},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		err := typeio.WriteCString(w, tc.s)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%q: error expected.", tc.s)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%q: unexpected type of error: got %q, want %q", tc.s, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%q: unexpected error: %s", tc.s, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%q: unexpected write: got %s, want %s", tc.s, got, tc.b)
+		}
+	}
+}
+
+func TestWriteStringN(t *testing.T) {
+	tcs := []struct {
+		s     string
+		n     int
+		p     byte
+		b     string
+		bt    string
+		e, et error
+	}{
+		{"", 0, 0, "", "", nil, nil},
+		{"", 3, 0, "000000", "000000", nil, nil},
+		{"ABC", 3, 0, "414243", "414243", nil, nil},
+		{"ABC", 6, ' ', "414243202020", "414243202020", nil, nil},
+		{"ABCD", 3, 0, "", "414243", typeio.ErrTooLong, nil},
+		{"漢字 ABC", 12, 0, "e6bca2e5ad97204142430000", "e6bca2e5ad97204142430000", nil, nil},
+		{"漢字 ABC", 5, 0, "", "e6bca20000", typeio.ErrTooLong, nil},
+		{"漢字 ABC", 6, 0, "", "e6bca2e5ad97", typeio.ErrTooLong, nil},
+		{"漢字 ABC", 2, ' ', "", "2020", typeio.ErrTooLong, nil},
+		{"ABC", -1, 0, "", "", typeio.ErrInvalidSize, typeio.ErrInvalidSize},
+	}
+	for _, tc := range tcs {
+		tag := fmt.Sprintf("%q, %d, %02x", tc.s, tc.n, tc.p)
+		w := new(bytes.Buffer)
+		err := typeio.WriteStringN(w, tc.s, tc.n, tc.p)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%s: error expected.", tag)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%s: unexpected type of error: got %q, want %q", tag, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%s: unexpected error: %s", tag, err)
+		case tc.e == nil:
+			if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+				t.Errorf("%s: unexpected write: got %s, want %s", tag, got, tc.b)
+			}
+		}
+
+		w.Reset()
+		err = typeio.WriteStringNTruncate(w, tc.s, tc.n, tc.p)
+		switch {
+		case tc.et != nil && err == nil:
+			t.Errorf("%s: error expected.", tag)
+		case tc.et != nil && !errors.Is(err, tc.et):
+			t.Errorf("%s: unexpected type of error: got %q, want %q", tag, err, tc.et)
+		case tc.et == nil && err != nil:
+			t.Errorf("%s: unexpected error: %s", tag, err)
+		case tc.et == nil && tc.bt != "":
+			if got := hex.EncodeToString(w.Bytes()); got != tc.bt {
+				t.Errorf("%s: unexpected truncated write: got %s, want %s", tag, got, tc.bt)
+			}
+		}
+	}
+}
+
+// readerKinds returns readers of b that exercise the different code paths of
+// ReadCString: io.ByteReader, bufio.Reader and a plain io.Reader.
+func readerKinds(b []byte) map[string]io.Reader {
+	return map[string]io.Reader{
+		"bytes": bytes.NewReader(b),
+		"bufio": bufio.NewReaderSize(iotest.HalfReader(bytes.NewReader(b)), 16),
+		"plain": iotest.OneByteReader(bytes.NewReader(b)),
+	}
+}
+
+func TestReadCString_readers(t *testing.T) {
+	long := strings.Repeat("0123456789", 5)
+	tcs := []struct {
+		b string
+		s []string
+	}{
+		{"", nil},
+		{"00", []string{""}},
+		{"410042430000", []string{"A", "BC", ""}},
+		{"41424344", []string{"ABCD"}},
+		{hex.EncodeToString([]byte(long + "\x00" + long)), []string{long, long}},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		for kind, r := range readerKinds(b) {
+			for i, want := range tc.s {
+				got, _, err := typeio.ReadCString(r)
+				if err != nil {
+					t.Errorf("%s: %q: #%d: unexpected error: %s", kind, tc.b, i, err)
+					break
+				}
+				if got != want {
+					t.Errorf("%s: %q: #%d: unexpected read: got %q, want %q", kind, tc.b, i, got, want)
+				}
+			}
+			if _, _, err := typeio.ReadCString(r); !errors.Is(err, io.EOF) {
+				t.Errorf("%s: %q: unexpected error at the end: got %v, want %v", kind, tc.b, err, io.EOF)
+			}
+		}
+	}
+}
+
+func TestReadCStringMax(t *testing.T) {
+	tcs := []struct {
+		b    string
+		max  int
+		s    string
+		n    int
+		e    error
+		rest string
+	}{
+		{"", 4, "", 0, io.EOF, ""},
+		{"00", 0, "", 1, nil, ""},
+		{"4100", 0, "", 1, typeio.ErrTooLong, "00"},
+		{"4100", 1, "A", 2, nil, ""},
+		{"41424300", 3, "ABC", 4, nil, ""},
+		{"414243", 3, "ABC", 3, nil, ""},
+		{"4142434400", 3, "ABC", 4, typeio.ErrTooLong, "00"},
+		{"41424344454600", 3, "ABC", 4, typeio.ErrTooLong, "454600"},
+		{"41004200", 8, "A", 2, nil, "4200"},
+		{"00", -1, "", 0, typeio.ErrInvalidSize, "00"},
+	}
+	for _, tc := range tcs {
+		tag := fmt.Sprintf("%q, %d", tc.b, tc.max)
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%s: invalid test data: %s", tag, err)
+			continue
+		}
+		for kind, r := range readerKinds(b) {
+			got, n, err := typeio.ReadCStringMax(r, tc.max)
+			switch {
+			case tc.e != nil && err == nil:
+				t.Errorf("%s: %s: error expected: got %q", kind, tag, got)
+			case tc.e != nil && !errors.Is(err, tc.e):
+				t.Errorf("%s: %s: unexpected type of error: got %q, want %q", kind, tag, err, tc.e)
+			case tc.e == nil && err != nil:
+				t.Errorf("%s: %s: unexpected error: %s", kind, tag, err)
+			}
+			if got != tc.s {
+				t.Errorf("%s: %s: unexpected read: got %q, want %q", kind, tag, got, tc.s)
+			}
+			if n != tc.n {
+				t.Errorf("%s: %s: unexpected read len: got %d, want %d", kind, tag, n, tc.n)
+			}
+			rest, _ := io.ReadAll(r)
+			if got := hex.EncodeToString(rest); got != tc.rest {
+				t.Errorf("%s: %s: unexpected rest: got %s, want %s", kind, tag, got, tc.rest)
+			}
+		}
+	}
+}
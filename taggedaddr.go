@@ -0,0 +1,202 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"strconv"
+)
+
+// ErrUnknownAddrType is the error thrown when the type byte of a tagged
+// address is not registered, or the kind of the address to be written has no
+// type byte registered.
+var ErrUnknownAddrType = errors.New("unknown address type")
+
+// AddrKind represents the kind of the address encoded in a tagged address.
+type AddrKind int
+
+const (
+	// AddrKindIPv4 indicates that a 4-byte IPv4 address follows the type
+	// byte.
+	AddrKindIPv4 AddrKind = iota
+
+	// AddrKindIPv6 indicates that a 16-byte IPv6 address follows the type
+	// byte.
+	AddrKindIPv6
+
+	// AddrKindDomain indicates that a domain name prefixed by a 1-byte length
+	// follows the type byte.
+	AddrKindDomain
+)
+
+// AddrTypes is a mapping between the type bytes of tagged addresses and the
+// kinds of addresses they indicate. A protocol that uses type bytes other than
+// those of SOCKS5 can build its own mapping with NewAddrTypes and Register. The
+// zero value is an empty mapping ready to use.
+type AddrTypes struct {
+	kinds map[byte]AddrKind
+	tags  map[AddrKind]byte
+}
+
+// NewAddrTypes returns a new empty AddrTypes.
+func NewAddrTypes() *AddrTypes {
+	return &AddrTypes{
+		kinds: make(map[byte]AddrKind),
+		tags:  make(map[AddrKind]byte),
+	}
+}
+
+// Register registers the type byte tag for the kind of address kind, and
+// returns ts to allow chaining. Multiple type bytes can be registered for the
+// same kind, in which case all of them are accepted on read, and the first
+// registered one is used on write. Registering a type byte that has already
+// been registered replaces its kind. If it was the one used on write for the
+// previous kind, the smallest of the remaining type bytes for that kind is used
+// instead.
+func (ts *AddrTypes) Register(tag byte, kind AddrKind) *AddrTypes {
+	if ts.kinds == nil {
+		ts.kinds = make(map[byte]AddrKind)
+		ts.tags = make(map[AddrKind]byte)
+	}
+	old, ok := ts.kinds[tag]
+	ts.kinds[tag] = kind
+	if ok && old != kind && ts.tags[old] == tag {
+		delete(ts.tags, old)
+		for i := 0; i < 256; i++ {
+			if k, ok := ts.kinds[byte(i)]; ok && k == old {
+				ts.tags[old] = byte(i)
+				break
+			}
+		}
+	}
+	if _, ok := ts.tags[kind]; !ok {
+		ts.tags[kind] = tag
+	}
+	return ts
+}
+
+// has returns whether ts has a type byte for kind.
+func (ts *AddrTypes) has(kind AddrKind) bool {
+	_, ok := ts.tags[kind]
+	return ok
+}
+
+// SOCKS5AddrTypes returns a new mapping of the ATYP values defined in RFC 1928,
+// which is the one used when nil is passed to ReadTaggedAddr and
+// WriteTaggedAddr. Since each call returns a new mapping, the caller can modify
+// it without affecting the others.
+func SOCKS5AddrTypes() *AddrTypes {
+	return NewAddrTypes().
+		Register(0x01, AddrKindIPv4).
+		Register(0x03, AddrKindDomain).
+		Register(0x04, AddrKindIPv6)
+}
+
+// socks5AddrTypes is the mapping used when nil is passed to ReadTaggedAddr and
+// WriteTaggedAddr. It is never modified.
+var socks5AddrTypes = SOCKS5AddrTypes()
+
+// TaggedAddr is a destination address used by proxy protocols, which is either
+// an IP address or a domain name, and a port number.
+type TaggedAddr struct {
+	IP   net.IP // IP address, nil for a domain name
+	Name string // domain name, used only if IP is nil
+	Port uint16
+}
+
+// String returns the string form of a in "host:port" format.
+func (a TaggedAddr) String() string {
+	host := a.Name
+	if a.IP != nil {
+		host = a.IP.String()
+	}
+	return net.JoinHostPort(host, strconv.Itoa(int(a.Port)))
+}
+
+// ReadTaggedAddr reads a tagged address from r, a type byte followed by an
+// IPv4 address, an IPv6 address or a domain name prefixed by a 1-byte length,
+// and a port number in big-endian byte order. The type byte is interpreted
+// according to ts. If ts is nil, the mapping returned by SOCKS5AddrTypes is
+// used. ErrUnknownAddrType is returned if the type byte is not registered in
+// ts or is registered for an unknown kind, and ErrInvalidIP is returned if the
+// domain name is empty.
+func ReadTaggedAddr(r io.Reader, ts *AddrTypes) (TaggedAddr, error) {
+	if ts == nil {
+		ts = socks5AddrTypes
+	}
+	tag, err := ReadUint8(r)
+	if err != nil {
+		return TaggedAddr{}, err
+	}
+	kind, ok := ts.kinds[tag]
+	if !ok {
+		return TaggedAddr{}, fmt.Errorf("%w: %#02x", ErrUnknownAddrType, tag)
+	}
+	var a TaggedAddr
+	switch kind {
+	case AddrKindIPv4:
+		a.IP, err = ReadIPv4(r)
+	case AddrKindIPv6:
+		a.IP, err = ReadIPv6(r)
+	case AddrKindDomain:
+		a.Name, err = ReadString8(r, -1)
+		if err == nil && a.Name == "" {
+			return TaggedAddr{}, fmt.Errorf("%w: empty domain name", ErrInvalidIP)
+		}
+	default:
+		return TaggedAddr{}, fmt.Errorf("%w: %#02x for unknown kind %d", ErrUnknownAddrType, tag, kind)
+	}
+	if err != nil {
+		return TaggedAddr{}, noEOF(err)
+	}
+	if a.Port, err = ReadUint16BE(r); err != nil {
+		return TaggedAddr{}, noEOF(err)
+	}
+	return a, nil
+}
+
+// WriteTaggedAddr writes a to w as a tagged address. See ReadTaggedAddr for
+// the details of the format. An IPv4 address, including an IPv4-mapped IPv6
+// address, is written as AddrKindIPv4 if ts has a type byte for it, otherwise
+// as AddrKindIPv6. If ts is nil, the mapping returned by SOCKS5AddrTypes is
+// used. ErrUnknownAddrType is returned if ts has no type byte for the kind of
+// a. ErrInvalidIP is returned if a has neither a valid IP address nor a name,
+// and ErrTooLong is returned if the name is longer than 255 bytes.
+func WriteTaggedAddr(w io.Writer, a TaggedAddr, ts *AddrTypes) error {
+	if ts == nil {
+		ts = socks5AddrTypes
+	}
+	var kind AddrKind
+	var b []byte
+	switch {
+	case a.IP == nil && a.Name == "":
+		return fmt.Errorf("%w: empty tagged address", ErrInvalidIP)
+	case a.IP == nil:
+		if math.MaxUint8 < len(a.Name) {
+			return fmt.Errorf("%w: name length %d exceeds %d", ErrTooLong, len(a.Name), math.MaxUint8)
+		}
+		kind = AddrKindDomain
+		b = append([]byte{0, byte(len(a.Name))}, a.Name...)
+	case a.IP.To4() != nil && ts.has(AddrKindIPv4):
+		kind = AddrKindIPv4
+		b = append([]byte{0}, a.IP.To4()...)
+	case a.IP.To16() != nil:
+		kind = AddrKindIPv6
+		b = append([]byte{0}, a.IP.To16()...)
+	default:
+		return fmt.Errorf("%w: %v", ErrInvalidIP, a.IP)
+	}
+	tag, ok := ts.tags[kind]
+	if !ok {
+		return fmt.Errorf("%w: no type byte for %v", ErrUnknownAddrType, a)
+	}
+	b[0] = tag
+	b = append(b, byte(a.Port>>8), byte(a.Port))
+	return write(w, b)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func ExampleReadTaggedAddr() {
+	b, _ := hex.DecodeString("01c00002010050030b6578616d706c652e636f6d01bb")
+	r := bytes.NewReader(b)
+
+	for {
+		a, err := typeio.ReadTaggedAddr(r, nil)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			panic(err)
+		}
+		fmt.Println(a)
+	}
+
+	// Output:
+	// 192.0.2.1:80
+	// example.com:443
+}
+
+func ExampleAddrTypes() {
+	// A protocol that uses 4 for IPv4, 6 for IPv6 and 0 for host names.
+	ts := typeio.NewAddrTypes().
+		Register(0x00, typeio.AddrKindDomain).
+		Register(0x04, typeio.AddrKindIPv4).
+		Register(0x06, typeio.AddrKindIPv6)
+
+	w := new(bytes.Buffer)
+	data := []typeio.TaggedAddr{
+		{IP: net.ParseIP("192.0.2.1"), Port: 80},
+		{Name: "example.com", Port: 443},
+	}
+	for _, a := range data {
+		if err := typeio.WriteTaggedAddr(w, a, ts); err != nil {
+			panic(err)
+		}
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	// Output:
+	// 04c00002010050000b6578616d706c652e636f6d01bb
+}
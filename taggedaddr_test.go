@@ -0,0 +1,163 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/tunabay/go-typeio"
+)
+
+// torAddrTypes is the mapping of the address types used by the RESOLVED cell
+// of the Tor protocol, for testing.
+var torAddrTypes = typeio.NewAddrTypes().
+	Register(0x00, typeio.AddrKindDomain).
+	Register(0x04, typeio.AddrKindIPv4).
+	Register(0x06, typeio.AddrKindIPv6)
+
+func TestReadTaggedAddr(t *testing.T) {
+	unknownKind := typeio.NewAddrTypes().Register(0x07, typeio.AddrKind(7))
+	tcs := []struct {
+		b  string
+		ts *typeio.AddrTypes
+		a  string
+		e  error
+	}{
+		{"01c00002010050", nil, "192.0.2.1:80", nil},
+		{"0420010db800000000000000000000000101bb", nil, "[2001:db8::1]:443", nil},
+		{"030b6578616d706c652e636f6d0050", nil, "example.com:80", nil},
+		{"0300ffff", nil, "", typeio.ErrInvalidIP},
+		{"04c00002010050", torAddrTypes, "192.0.2.1:80", nil},
+		{"000b6578616d706c652e636f6d0050", torAddrTypes, "example.com:80", nil},
+		{"02c00002010050", nil, "", typeio.ErrUnknownAddrType},
+		{"06c00002010050", nil, "", typeio.ErrUnknownAddrType},
+		{"07c00002010050", unknownKind, "", typeio.ErrUnknownAddrType},
+		{"", nil, "", io.EOF},
+		{"01", nil, "", io.ErrUnexpectedEOF},
+		{"01c0000201", nil, "", io.ErrUnexpectedEOF},
+		{"01c000020100", nil, "", io.ErrUnexpectedEOF},
+		{"030b6578616d706c65", nil, "", io.ErrUnexpectedEOF},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		got, err := typeio.ReadTaggedAddr(bytes.NewReader(b), tc.ts)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%q: error expected: got %s", tc.b, got)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%q: unexpected type of error: got %q, want %q", tc.b, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%q: unexpected error: %s", tc.b, err)
+		case tc.e == nil && got.String() != tc.a:
+			t.Errorf("%q: unexpected read: got %s, want %s", tc.b, got, tc.a)
+		}
+	}
+}
+
+func TestWriteTaggedAddr(t *testing.T) {
+	v6only := typeio.NewAddrTypes().Register(0x04, typeio.AddrKindIPv6)
+	tcs := []struct {
+		a  typeio.TaggedAddr
+		ts *typeio.AddrTypes
+		b  string
+		e  error
+	}{
+		{typeio.TaggedAddr{IP: net.ParseIP("192.0.2.1"), Port: 80}, nil, "01c00002010050", nil},
+		{typeio.TaggedAddr{IP: net.IPv4(192, 0, 2, 1).To4(), Port: 80}, nil, "01c00002010050", nil},
+		{typeio.TaggedAddr{IP: net.ParseIP("2001:db8::1"), Port: 443}, nil, "0420010db800000000000000000000000101bb", nil},
+		{typeio.TaggedAddr{Name: "example.com", Port: 80}, nil, "030b6578616d706c652e636f6d0050", nil},
+		{typeio.TaggedAddr{IP: net.ParseIP("192.0.2.1"), Name: "example.com", Port: 80}, nil, "01c00002010050", nil},
+		{typeio.TaggedAddr{IP: net.ParseIP("192.0.2.1"), Port: 80}, torAddrTypes, "04c00002010050", nil},
+		{typeio.TaggedAddr{Name: "example.com", Port: 80}, torAddrTypes, "000b6578616d706c652e636f6d0050", nil},
+		{typeio.TaggedAddr{IP: net.ParseIP("192.0.2.1"), Port: 80}, v6only, "0400000000000000000000ffffc00002010050", nil},
+		{typeio.TaggedAddr{Name: "example.com", Port: 80}, v6only, "", typeio.ErrUnknownAddrType},
+		{typeio.TaggedAddr{Name: strings.Repeat("a", 256)}, nil, "", typeio.ErrTooLong},
+		{typeio.TaggedAddr{IP: net.IP{1, 2, 3}}, nil, "", typeio.ErrInvalidIP},
+		{typeio.TaggedAddr{Port: 80}, nil, "", typeio.ErrInvalidIP},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		err := typeio.WriteTaggedAddr(w, tc.a, tc.ts)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%s: error expected.", tc.a)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%s: unexpected type of error: got %q, want %q", tc.a, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%s: unexpected error: %s", tc.a, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%s: unexpected write: got %s, want %s", tc.a, got, tc.b)
+		}
+	}
+}
+
+func TestAddrTypes_Register(t *testing.T) {
+	ts := typeio.NewAddrTypes().
+		Register(0x01, typeio.AddrKindIPv4).
+		Register(0x11, typeio.AddrKindIPv4).
+		Register(0x01, typeio.AddrKindDomain)
+
+	a := typeio.TaggedAddr{IP: net.ParseIP("192.0.2.1"), Port: 80}
+	w := new(bytes.Buffer)
+	if err := typeio.WriteTaggedAddr(w, a, ts); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, want := hex.EncodeToString(w.Bytes()), "11c00002010050"; got != want {
+		t.Errorf("unexpected write: got %s, want %s", got, want)
+	}
+
+	b, _ := hex.DecodeString("010b6578616d706c652e636f6d0050")
+	got, err := typeio.ReadTaggedAddr(bytes.NewReader(b), ts)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got.Name != "example.com" {
+		t.Errorf("unexpected read: got %s, want example.com:80", got)
+	}
+}
+
+func TestAddrTypes_zero(t *testing.T) {
+	ts := new(typeio.AddrTypes).Register(0x04, typeio.AddrKindIPv4)
+
+	a := typeio.TaggedAddr{IP: net.ParseIP("192.0.2.1"), Port: 80}
+	w := new(bytes.Buffer)
+	if err := typeio.WriteTaggedAddr(w, a, ts); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, want := hex.EncodeToString(w.Bytes()), "04c00002010050"; got != want {
+		t.Errorf("unexpected write: got %s, want %s", got, want)
+	}
+
+	var empty typeio.AddrTypes
+	if err := typeio.WriteTaggedAddr(w, a, &empty); !errors.Is(err, typeio.ErrUnknownAddrType) {
+		t.Errorf("unexpected error: got %v, want %v", err, typeio.ErrUnknownAddrType)
+	}
+}
+
+func TestSOCKS5AddrTypes(t *testing.T) {
+	typeio.SOCKS5AddrTypes().Register(0x01, typeio.AddrKindDomain)
+
+	b, _ := hex.DecodeString("01c00002010050")
+	for _, ts := range []*typeio.AddrTypes{nil, typeio.SOCKS5AddrTypes()} {
+		got, err := typeio.ReadTaggedAddr(bytes.NewReader(b), ts)
+		switch {
+		case err != nil:
+			t.Errorf("unexpected error: %s", err)
+		case got.String() != "192.0.2.1:80":
+			t.Errorf("unexpected read: got %s, want 192.0.2.1:80", got)
+		}
+	}
+}
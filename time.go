@@ -5,31 +5,34 @@
 package typeio
 
 import (
+	"errors"
+	"fmt"
 	"io"
+	"math"
 	"time"
 )
 
+// ErrTimeOutOfRange is the error thrown when a time value to be written can not
+// be represented in the specified format.
+var ErrTimeOutOfRange = errors.New("time out of range")
+
+// ErrInvalidTime is the error thrown when the data read does not represent a
+// valid time value.
+var ErrInvalidTime = errors.New("invalid time")
+
 // ReadUnixTimeUTC32BE reads 4 bytes in big-endian byte order from r, interprets
 // it as a UNIX time, the number of seconds elapsed since Jan 1, 1970 UTC, and
 // returns the UTC time it represents.
 // Note that this data type has the well-known Y2038 problem.
 func ReadUnixTimeUTC32BE(r io.Reader) (time.Time, error) {
-	t, err := ReadUint32BE(r)
-	if err != nil {
-		return time.Time{}, err
-	}
-	return time.Unix(int64(t), 0).UTC(), nil
+	return readDecode(r, 4, DecodeUnixTimeUTC32BE)
 }
 
 // ReadUnixTime32BE is identical to ReadUnixTimeUTC32BE except that it returns
 // the local time rather than UTC.
 // Note that this data type has the well-known Y2038 problem.
 func ReadUnixTime32BE(r io.Reader) (time.Time, error) {
-	t, err := ReadUint32BE(r)
-	if err != nil {
-		return time.Time{}, err
-	}
-	return time.Unix(int64(t), 0).Local(), nil
+	return readDecode(r, 4, DecodeUnixTime32BE)
 }
 
 // ReadUnixTimeUTC32LE reads 4 bytes in little-endian byte order from r,
@@ -37,22 +40,14 @@ func ReadUnixTime32BE(r io.Reader) (time.Time, error) {
 // UTC, and returns the UTC time it represents.
 // Note that this data type has the well-known Y2038 problem.
 func ReadUnixTimeUTC32LE(r io.Reader) (time.Time, error) {
-	t, err := ReadUint32LE(r)
-	if err != nil {
-		return time.Time{}, err
-	}
-	return time.Unix(int64(t), 0).UTC(), nil
+	return readDecode(r, 4, DecodeUnixTimeUTC32LE)
 }
 
 // ReadUnixTime32LE is identical to ReadUnixTimeUTC32LE except that it returns
 // the local time rather than UTC.
 // Note that this data type has the well-known Y2038 problem.
 func ReadUnixTime32LE(r io.Reader) (time.Time, error) {
-	t, err := ReadUint32LE(r)
-	if err != nil {
-		return time.Time{}, err
-	}
-	return time.Unix(int64(t), 0).Local(), nil
+	return readDecode(r, 4, DecodeUnixTime32LE)
 }
 
 // WriteUnixTime32BE writes 4 bytes to w that represent the UNIX time for t, the
@@ -61,9 +56,10 @@ func ReadUnixTime32LE(r io.Reader) (time.Time, error) {
 // appropriate UTC value for t is always used, regardless of the associated
 // location.
 // Note that this data type has the well-known Y2038 problem. Time values before
-// the 1970 epoch time or afte the Y2038 are not written correctly.
+// the 1970 epoch time or afte the Y2038 are not written correctly. Use
+// WriteUnixTimeUint32BE to detect such time values.
 func WriteUnixTime32BE(w io.Writer, t time.Time) error {
-	return WriteUint32BE(w, uint32(t.Unix()))
+	return write(w, AppendUnixTime32BE(nil, t))
 }
 
 // WriteUnixTime32LE writes 4 bytes to w that represent the UNIX time for t, the
@@ -72,7 +68,956 @@ func WriteUnixTime32BE(w io.Writer, t time.Time) error {
 // appropriate UTC value for t is always used, regardless of the associated
 // location.
 // Note that this data type has the well-known Y2038 problem. Time values before
-// the 1970 epoch time or afte the Y2038 are not written correctly.
+// the 1970 epoch time or afte the Y2038 are not written correctly. Use
+// WriteUnixTimeUint32LE to detect such time values.
 func WriteUnixTime32LE(w io.Writer, t time.Time) error {
-	return WriteUint32LE(w, uint32(t.Unix()))
+	return write(w, AppendUnixTime32LE(nil, t))
+}
+
+// WriteUnixTimeUint32BE is identical to WriteUnixTime32BE except that it
+// returns ErrTimeOutOfRange instead of writing a wrapped-around value if t is
+// not in the range representable as an unsigned 32-bit UNIX time, from
+// 1970-01-01 00:00:00 to 2106-02-07 06:28:15 UTC. Fractions of a second are
+// truncated toward the past.
+func WriteUnixTimeUint32BE(w io.Writer, t time.Time) error {
+	b, err := AppendUnixTimeUint32BE(nil, t)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadUnixTimeUTCInt32BE reads 4 bytes in big-endian byte order from r,
+// interprets it as a signed 32-bit UNIX time, the classic time_t, and returns
+// the UTC time it represents. Unlike ReadUnixTimeUTC32BE, it can represent time
+// values before 1970, from 1901-12-13 20:45:52 to 2038-01-19 03:14:07 UTC.
+func ReadUnixTimeUTCInt32BE(r io.Reader) (time.Time, error) {
+	return readDecode(r, 4, DecodeUnixTimeUTCInt32BE)
+}
+
+// ReadUnixTimeInt32BE is identical to ReadUnixTimeUTCInt32BE except that it
+// returns the local time rather than UTC.
+func ReadUnixTimeInt32BE(r io.Reader) (time.Time, error) {
+	return readDecode(r, 4, DecodeUnixTimeInt32BE)
+}
+
+// WriteUnixTimeInt32BE writes 4 bytes to w that represent the signed 32-bit
+// UNIX time for t in big-endian byte order. ErrTimeOutOfRange is returned if t
+// is not in the range from 1901-12-13 20:45:52 to 2038-01-19 03:14:07 UTC.
+// Fractions of a second are truncated toward the past.
+func WriteUnixTimeInt32BE(w io.Writer, t time.Time) error {
+	b, err := AppendUnixTimeInt32BE(nil, t)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// WriteUnixTimeUint32LE is identical to WriteUnixTime32LE except that it
+// returns ErrTimeOutOfRange instead of writing a wrapped-around value if t is
+// not in the range representable as an unsigned 32-bit UNIX time, from
+// 1970-01-01 00:00:00 to 2106-02-07 06:28:15 UTC. Fractions of a second are
+// truncated toward the past.
+func WriteUnixTimeUint32LE(w io.Writer, t time.Time) error {
+	b, err := AppendUnixTimeUint32LE(nil, t)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadUnixTimeUTCInt32LE reads 4 bytes in little-endian byte order from r,
+// interprets it as a signed 32-bit UNIX time, the classic time_t, and returns
+// the UTC time it represents. Unlike ReadUnixTimeUTC32LE, it can represent time
+// values before 1970, from 1901-12-13 20:45:52 to 2038-01-19 03:14:07 UTC.
+func ReadUnixTimeUTCInt32LE(r io.Reader) (time.Time, error) {
+	return readDecode(r, 4, DecodeUnixTimeUTCInt32LE)
+}
+
+// ReadUnixTimeInt32LE is identical to ReadUnixTimeUTCInt32LE except that it
+// returns the local time rather than UTC.
+func ReadUnixTimeInt32LE(r io.Reader) (time.Time, error) {
+	return readDecode(r, 4, DecodeUnixTimeInt32LE)
+}
+
+// WriteUnixTimeInt32LE writes 4 bytes to w that represent the signed 32-bit
+// UNIX time for t in little-endian byte order. ErrTimeOutOfRange is returned if
+// t is not in the range from 1901-12-13 20:45:52 to 2038-01-19 03:14:07 UTC.
+// Fractions of a second are truncated toward the past.
+func WriteUnixTimeInt32LE(w io.Writer, t time.Time) error {
+	b, err := AppendUnixTimeInt32LE(nil, t)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// unixTime returns the time represented by v in units of 1/perSec second
+// elapsed since Jan 1, 1970 UTC.
+func unixTime(v, perSec int64) time.Time {
+	return time.Unix(v/perSec, v%perSec*(1e9/perSec))
+}
+
+// unixValue returns the number of 1/perSec second units elapsed since Jan 1,
+// 1970 UTC for t. Fractions smaller than the unit are truncated toward the
+// past. ErrTimeOutOfRange is returned if the value does not fit in int64.
+func unixValue(t time.Time, perSec int64) (int64, error) {
+	sec, frac := t.Unix(), int64(t.Nanosecond())/(1e9/perSec)
+	if sec < 0 && 0 < frac {
+		sec, frac = sec+1, frac-perSec
+	}
+	if sec < math.MinInt64/perSec || math.MaxInt64/perSec < sec {
+		return 0, fmt.Errorf("%w: %v", ErrTimeOutOfRange, t)
+	}
+	v := sec * perSec
+	if (0 < frac && math.MaxInt64-frac < v) || (frac < 0 && v < math.MinInt64-frac) {
+		return 0, fmt.Errorf("%w: %v", ErrTimeOutOfRange, t)
+	}
+	return v + frac, nil
+}
+
+// ReadUnixTimeUTC64BE reads 8 bytes in big-endian byte order from r, interprets
+// it as a signed 64-bit UNIX time, the number of seconds elapsed since Jan 1,
+// 1970 UTC, and returns the UTC time it represents.
+func ReadUnixTimeUTC64BE(r io.Reader) (time.Time, error) {
+	return readDecode(r, 8, DecodeUnixTimeUTC64BE)
+}
+
+// ReadUnixTime64BE is identical to ReadUnixTimeUTC64BE except that it returns
+// the local time rather than UTC.
+func ReadUnixTime64BE(r io.Reader) (time.Time, error) {
+	return readDecode(r, 8, DecodeUnixTime64BE)
+}
+
+// WriteUnixTime64BE writes 8 bytes to w that represent the signed 64-bit UNIX
+// time for t, the number of seconds elapsed since Jan 1, 1970 UTC, in
+// big-endian byte order. Fractions of a second are truncated toward the past.
+// The written bytes do not depend on the location associated with t.
+func WriteUnixTime64BE(w io.Writer, t time.Time) error {
+	b, err := AppendUnixTime64BE(nil, t)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadUnixTimeUTC64LE reads 8 bytes in little-endian byte order from r,
+// interprets it as a signed 64-bit UNIX time, the number of seconds elapsed
+// since Jan 1, 1970 UTC, and returns the UTC time it represents.
+func ReadUnixTimeUTC64LE(r io.Reader) (time.Time, error) {
+	return readDecode(r, 8, DecodeUnixTimeUTC64LE)
+}
+
+// ReadUnixTime64LE is identical to ReadUnixTimeUTC64LE except that it returns
+// the local time rather than UTC.
+func ReadUnixTime64LE(r io.Reader) (time.Time, error) {
+	return readDecode(r, 8, DecodeUnixTime64LE)
+}
+
+// WriteUnixTime64LE writes 8 bytes to w that represent the signed 64-bit UNIX
+// time for t, the number of seconds elapsed since Jan 1, 1970 UTC, in
+// little-endian byte order. Fractions of a second are truncated toward the
+// past. The written bytes do not depend on the location associated with t.
+func WriteUnixTime64LE(w io.Writer, t time.Time) error {
+	b, err := AppendUnixTime64LE(nil, t)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadUnixMilliUTC64BE reads 8 bytes in big-endian byte order from r,
+// interprets it as the number of milliseconds elapsed since Jan 1, 1970 UTC,
+// and returns the UTC time it represents.
+func ReadUnixMilliUTC64BE(r io.Reader) (time.Time, error) {
+	return readDecode(r, 8, DecodeUnixMilliUTC64BE)
+}
+
+// ReadUnixMilli64BE is identical to ReadUnixMilliUTC64BE except that it returns
+// the local time rather than UTC.
+func ReadUnixMilli64BE(r io.Reader) (time.Time, error) {
+	return readDecode(r, 8, DecodeUnixMilli64BE)
+}
+
+// WriteUnixMilli64BE writes 8 bytes to w that represent t as the number of
+// milliseconds elapsed since Jan 1, 1970 UTC, as a signed 64-bit integer in
+// big-endian byte order. Fractions smaller than a millisecond are truncated
+// toward the past. The written bytes do not depend on the location associated
+// with t. ErrTimeOutOfRange is returned if t does not fit in the format.
+func WriteUnixMilli64BE(w io.Writer, t time.Time) error {
+	b, err := AppendUnixMilli64BE(nil, t)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadUnixMilliUTC64LE reads 8 bytes in little-endian byte order from r,
+// interprets it as the number of milliseconds elapsed since Jan 1, 1970 UTC,
+// and returns the UTC time it represents.
+func ReadUnixMilliUTC64LE(r io.Reader) (time.Time, error) {
+	return readDecode(r, 8, DecodeUnixMilliUTC64LE)
+}
+
+// ReadUnixMilli64LE is identical to ReadUnixMilliUTC64LE except that it returns
+// the local time rather than UTC.
+func ReadUnixMilli64LE(r io.Reader) (time.Time, error) {
+	return readDecode(r, 8, DecodeUnixMilli64LE)
+}
+
+// WriteUnixMilli64LE writes 8 bytes to w that represent t as the number of
+// milliseconds elapsed since Jan 1, 1970 UTC, as a signed 64-bit integer in
+// little-endian byte order. Fractions smaller than a millisecond are truncated
+// toward the past. The written bytes do not depend on the location associated
+// with t. ErrTimeOutOfRange is returned if t does not fit in the format.
+func WriteUnixMilli64LE(w io.Writer, t time.Time) error {
+	b, err := AppendUnixMilli64LE(nil, t)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadUnixMicroUTC64BE reads 8 bytes in big-endian byte order from r,
+// interprets it as the number of microseconds elapsed since Jan 1, 1970 UTC,
+// and returns the UTC time it represents.
+func ReadUnixMicroUTC64BE(r io.Reader) (time.Time, error) {
+	return readDecode(r, 8, DecodeUnixMicroUTC64BE)
+}
+
+// ReadUnixMicro64BE is identical to ReadUnixMicroUTC64BE except that it returns
+// the local time rather than UTC.
+func ReadUnixMicro64BE(r io.Reader) (time.Time, error) {
+	return readDecode(r, 8, DecodeUnixMicro64BE)
+}
+
+// WriteUnixMicro64BE writes 8 bytes to w that represent t as the number of
+// microseconds elapsed since Jan 1, 1970 UTC, as a signed 64-bit integer in
+// big-endian byte order. Fractions smaller than a microsecond are truncated
+// toward the past. The written bytes do not depend on the location associated
+// with t. ErrTimeOutOfRange is returned if t does not fit in the format.
+func WriteUnixMicro64BE(w io.Writer, t time.Time) error {
+	b, err := AppendUnixMicro64BE(nil, t)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadUnixMicroUTC64LE reads 8 bytes in little-endian byte order from r,
+// interprets it as the number of microseconds elapsed since Jan 1, 1970 UTC,
+// and returns the UTC time it represents.
+func ReadUnixMicroUTC64LE(r io.Reader) (time.Time, error) {
+	return readDecode(r, 8, DecodeUnixMicroUTC64LE)
+}
+
+// ReadUnixMicro64LE is identical to ReadUnixMicroUTC64LE except that it returns
+// the local time rather than UTC.
+func ReadUnixMicro64LE(r io.Reader) (time.Time, error) {
+	return readDecode(r, 8, DecodeUnixMicro64LE)
+}
+
+// WriteUnixMicro64LE writes 8 bytes to w that represent t as the number of
+// microseconds elapsed since Jan 1, 1970 UTC, as a signed 64-bit integer in
+// little-endian byte order. Fractions smaller than a microsecond are truncated
+// toward the past. The written bytes do not depend on the location associated
+// with t. ErrTimeOutOfRange is returned if t does not fit in the format.
+func WriteUnixMicro64LE(w io.Writer, t time.Time) error {
+	b, err := AppendUnixMicro64LE(nil, t)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadUnixNanoUTC64BE reads 8 bytes in big-endian byte order from r, interprets
+// it as the number of nanoseconds elapsed since Jan 1, 1970 UTC, and returns
+// the UTC time it represents.
+func ReadUnixNanoUTC64BE(r io.Reader) (time.Time, error) {
+	return readDecode(r, 8, DecodeUnixNanoUTC64BE)
+}
+
+// ReadUnixNano64BE is identical to ReadUnixNanoUTC64BE except that it returns
+// the local time rather than UTC.
+func ReadUnixNano64BE(r io.Reader) (time.Time, error) {
+	return readDecode(r, 8, DecodeUnixNano64BE)
+}
+
+// WriteUnixNano64BE writes 8 bytes to w that represent t as the number of
+// nanoseconds elapsed since Jan 1, 1970 UTC, as a signed 64-bit integer in
+// big-endian byte order. Fractions smaller than a nanosecond are truncated
+// toward the past. The written bytes do not depend on the location associated
+// with t. ErrTimeOutOfRange is returned if t does not fit in the format.
+func WriteUnixNano64BE(w io.Writer, t time.Time) error {
+	b, err := AppendUnixNano64BE(nil, t)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadUnixNanoUTC64LE reads 8 bytes in little-endian byte order from r,
+// interprets it as the number of nanoseconds elapsed since Jan 1, 1970 UTC, and
+// returns the UTC time it represents.
+func ReadUnixNanoUTC64LE(r io.Reader) (time.Time, error) {
+	return readDecode(r, 8, DecodeUnixNanoUTC64LE)
+}
+
+// ReadUnixNano64LE is identical to ReadUnixNanoUTC64LE except that it returns
+// the local time rather than UTC.
+func ReadUnixNano64LE(r io.Reader) (time.Time, error) {
+	return readDecode(r, 8, DecodeUnixNano64LE)
+}
+
+// WriteUnixNano64LE writes 8 bytes to w that represent t as the number of
+// nanoseconds elapsed since Jan 1, 1970 UTC, as a signed 64-bit integer in
+// little-endian byte order. Fractions smaller than a nanosecond are truncated
+// toward the past. The written bytes do not depend on the location associated
+// with t. ErrTimeOutOfRange is returned if t does not fit in the format.
+func WriteUnixNano64LE(w io.Writer, t time.Time) error {
+	b, err := AppendUnixNano64LE(nil, t)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ntpEpochOffset is the number of seconds from the NTP prime epoch, Jan 1, 1900
+// UTC, to the UNIX epoch.
+const ntpEpochOffset = 2208988800
+
+// ReadNTPTimestamp reads 8 bytes of the NTP timestamp format defined in RFC
+// 5905, a 32-bit unsigned number of seconds since Jan 1, 1900 UTC and a 32-bit
+// fraction of a second both in big-endian byte order, from r and returns the
+// UTC time it represents.
+//
+// Since the seconds field wraps around in 2036, the era is determined by the
+// most significant bit as suggested in RFC 4330: if it is set, the time is in
+// the range from 1968-01-20 03:14:08 to 2036-02-07 06:28:15 UTC, otherwise in
+// the range from 2036-02-07 06:28:16 to 2104-02-26 09:42:23 UTC. Note that the
+// value 0, which NTP uses to represent an unknown time, is also interpreted in
+// this way.
+//
+// The fraction has a resolution of about 233 picoseconds and is rounded to the
+// nearest nanosecond, with ties rounded up.
+func ReadNTPTimestamp(r io.Reader) (time.Time, error) {
+	return readDecode(r, 8, DecodeNTPTimestamp)
+}
+
+// WriteNTPTimestamp writes 8 bytes to w that represent t in the NTP timestamp
+// format. See ReadNTPTimestamp for the details of the format and the range of
+// time values that can be written. ErrTimeOutOfRange is returned if t is out of
+// the range. The nanoseconds of t are rounded to the nearest fraction, so that
+// reading the written bytes with ReadNTPTimestamp yields exactly t.
+func WriteNTPTimestamp(w io.Writer, t time.Time) error {
+	b, err := AppendNTPTimestamp(nil, t)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadNTPShort reads 4 bytes of the NTP short format defined in RFC 5905, a
+// 16-bit unsigned number of seconds and a 16-bit fraction of a second both in
+// big-endian byte order, from r and returns it as a time.Duration. This format
+// is used for the root delay and root dispersion fields. The fraction has a
+// resolution of about 15 microseconds and is rounded to the nearest nanosecond,
+// with ties rounded up.
+func ReadNTPShort(r io.Reader) (time.Duration, error) {
+	return readDecode(r, 4, DecodeNTPShort)
+}
+
+// WriteNTPShort writes 4 bytes to w that represent d in the NTP short format.
+// The d is rounded to the nearest fraction. ErrValueOutOfRange is returned if d
+// is negative or is 65536 seconds or more after rounding.
+func WriteNTPShort(w io.Writer, d time.Duration) error {
+	b, err := AppendNTPShort(nil, d)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// fileTimeEpochOffset is the number of seconds from the Windows FILETIME epoch,
+// Jan 1, 1601 UTC, to the UNIX epoch.
+const fileTimeEpochOffset = 11644473600
+
+// ReadWindowsFileTime reads 8 bytes in little-endian byte order from r,
+// interprets it as a Windows FILETIME, the number of 100-nanosecond intervals
+// elapsed since Jan 1, 1601 UTC, and returns the UTC time it represents.
+func ReadWindowsFileTime(r io.Reader) (time.Time, error) {
+	return readDecode(r, 8, DecodeWindowsFileTime)
+}
+
+// WriteWindowsFileTime writes 8 bytes to w that represent t as a Windows
+// FILETIME in little-endian byte order. Fractions smaller than 100 nanoseconds
+// are truncated. The written bytes do not depend on the location associated
+// with t. ErrTimeOutOfRange is returned if t is before Jan 1, 1601 UTC or too
+// far in the future to be represented.
+func WriteWindowsFileTime(w io.Writer, t time.Time) error {
+	b, err := AppendWindowsFileTime(nil, t)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// DotNetKind represents the Kind property of a .NET DateTime value, which
+// indicates how the date and time fields should be interpreted.
+type DotNetKind uint8
+
+const (
+	// DotNetKindUnspecified indicates that the time is neither UTC nor
+	// local time.
+	DotNetKindUnspecified DotNetKind = iota
+
+	// DotNetKindUTC indicates that the time is UTC.
+	DotNetKindUTC
+
+	// DotNetKindLocal indicates that the time is local time.
+	DotNetKindLocal
+)
+
+const (
+	// dotNetEpochOffset is the number of seconds from Jan 1, 0001 to the UNIX
+	// epoch.
+	dotNetEpochOffset = 62135596800
+
+	// dotNetMaxTicks is the number of ticks of 9999-12-31 23:59:59.9999999,
+	// the maximum value of .NET DateTime.
+	dotNetMaxTicks = 3155378975999999999
+)
+
+// ReadDotNetDateTime reads 8 bytes in little-endian byte order from r,
+// interprets it as a binary representation of a .NET DateTime value, the
+// number of 100-nanosecond ticks elapsed since Jan 1, 0001 in the lower 62 bits
+// and the Kind in the upper 2 bits, and returns the time it represents along
+// with the Kind. This is the format written by the .NET BinaryWriter and
+// binary serialization.
+//
+// The ticks represent the wall clock in the frame indicated by the Kind. The
+// returned time is in UTC for DotNetKindUTC, in time.Local for
+// DotNetKindLocal, and the wall clock is returned as UTC for
+// DotNetKindUnspecified. The Kind value 3, used by .NET for local times in the
+// ambiguous period at the end of daylight saving time, is read as
+// DotNetKindLocal. ErrInvalidTime is returned if the ticks exceed the maximum
+// value of DateTime.
+func ReadDotNetDateTime(r io.Reader) (time.Time, DotNetKind, error) {
+	b, err := readN(r, 8)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	t, kind, _, err := DecodeDotNetDateTime(b)
+	return t, kind, err
+}
+
+// WriteDotNetDateTime writes 8 bytes to w that represent t as a binary
+// representation of a .NET DateTime value with the Kind kind in little-endian
+// byte order. The ticks are the wall clock of t converted to UTC for
+// DotNetKindUTC, converted to time.Local for DotNetKindLocal, and as is in the
+// location associated with t for DotNetKindUnspecified. Fractions smaller than
+// 100 nanoseconds are truncated. ErrTimeOutOfRange is returned if the wall clock
+// is not in the range from year 1 to 9999.
+func WriteDotNetDateTime(w io.Writer, t time.Time, kind DotNetKind) error {
+	b, err := AppendDotNetDateTime(nil, t, kind)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadDOSDateTime reads 4 bytes of the MS-DOS date and time format, a 16-bit
+// date followed by a 16-bit time both in little-endian byte order, from r and
+// returns the time in the location loc that the date and time represent. If loc
+// is nil, UTC is used. Since the format does not carry any time zone
+// information, the location must be known from the context, and it is usually
+// time.Local.
+//
+// The date holds the year since 1980, the month and the day of the month in the
+// bits 15-9, 8-5 and 4-0 respectively, and the time holds the hour, the minute
+// and the second divided by 2 in the bits 15-11, 10-5 and 4-0 respectively.
+// ErrInvalidTime is returned if any of the fields is out of range, including the
+// all zero date that some archivers use to represent an unknown time.
+func ReadDOSDateTime(r io.Reader, loc *time.Location) (time.Time, error) {
+	b, err := readN(r, 4)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, _, err := DecodeDOSDateTime(b, loc)
+	return t, err
+}
+
+// ReadDOSTimeDate is identical to ReadDOSDateTime except that it reads the time
+// before the date. This is the order used by ZIP headers and FAT directory
+// entries.
+func ReadDOSTimeDate(r io.Reader, loc *time.Location) (time.Time, error) {
+	b, err := readN(r, 4)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, _, err := DecodeDOSTimeDate(b, loc)
+	return t, err
+}
+
+// WriteDOSDateTime writes 4 bytes to w that represent t in the MS-DOS date and
+// time format, a 16-bit date followed by a 16-bit time both in little-endian
+// byte order. The wall clock of t in its associated location is written as is,
+// so t should be converted with t.In in advance if necessary. Since the format
+// has a resolution of 2 seconds, odd seconds and fractions are truncated.
+// ErrTimeOutOfRange is returned if the wall clock is before 1980 or after 2107.
+func WriteDOSDateTime(w io.Writer, t time.Time) error {
+	b, err := AppendDOSDateTime(nil, t)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// WriteDOSTimeDate is identical to WriteDOSDateTime except that it writes the
+// time before the date. This is the order used by ZIP headers and FAT directory
+// entries.
+func WriteDOSTimeDate(w io.Writer, t time.Time) error {
+	b, err := AppendDOSTimeDate(nil, t)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// dosTime returns the time in loc represented by the MS-DOS date d and time t.
+func dosTime(d, t uint16, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	y, mo, dd := 1980+int(d>>9), time.Month(d>>5&0xf), int(d&0x1f)
+	h, mi, s := int(t>>11), int(t>>5&0x3f), int(t&0x1f)*2
+	switch {
+	case mo < time.January, time.December < mo, dd < 1, 23 < h, 59 < mi, 59 < s:
+		return time.Time{}, fmt.Errorf("%w: MS-DOS date %#04x, time %#04x", ErrInvalidTime, d, t)
+	case time.Date(y, mo, dd, 0, 0, 0, 0, time.UTC).Day() != dd:
+		return time.Time{}, fmt.Errorf("%w: MS-DOS date %#04x, time %#04x", ErrInvalidTime, d, t)
+	}
+	return time.Date(y, mo, dd, h, mi, s, 0, loc), nil
+}
+
+// dosValue returns the MS-DOS date and time that represent the wall clock of t.
+func dosValue(t time.Time) (uint16, uint16, error) {
+	y, mo, d := t.Date()
+	h, mi, s := t.Clock()
+	if y < 1980 || 2107 < y {
+		return 0, 0, fmt.Errorf("%w: %v", ErrTimeOutOfRange, t)
+	}
+	return uint16(y-1980)<<9 | uint16(mo)<<5 | uint16(d), uint16(h)<<11 | uint16(mi)<<5 | uint16(s/2), nil
+}
+
+// hfsEpochOffset is the number of seconds from the HFS epoch, Jan 1, 1904, to
+// the UNIX epoch.
+const hfsEpochOffset = 2082844800
+
+// ReadHFSPlusTime reads 4 bytes in big-endian byte order from r, interprets it
+// as an HFS+ date, the 32-bit unsigned number of seconds elapsed since Jan 1,
+// 1904 UTC, and returns the UTC time it represents.
+func ReadHFSPlusTime(r io.Reader) (time.Time, error) {
+	return readDecode(r, 4, DecodeHFSPlusTime)
+}
+
+// WriteHFSPlusTime writes 4 bytes to w that represent t as an HFS+ date in
+// big-endian byte order. Fractions of a second are truncated toward the past.
+// The written bytes do not depend on the location associated with t.
+// ErrTimeOutOfRange is returned if t is not in the range from 1904-01-01
+// 00:00:00 to 2040-02-06 06:28:15 UTC.
+func WriteHFSPlusTime(w io.Writer, t time.Time) error {
+	b, err := AppendHFSPlusTime(nil, t)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadHFSTime reads 4 bytes in big-endian byte order from r, interprets it as a
+// classic Mac OS HFS date, the 32-bit unsigned number of seconds elapsed since
+// Jan 1, 1904 in local time, and returns the time in the location loc that it
+// represents. If loc is nil, UTC is used. Unlike HFS+, the HFS date does not
+// carry any time zone information, so the location must be known from the
+// context.
+func ReadHFSTime(r io.Reader, loc *time.Location) (time.Time, error) {
+	b, err := readN(r, 4)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, _, err := DecodeHFSTime(b, loc)
+	return t, err
+}
+
+// WriteHFSTime writes 4 bytes to w that represent t as a classic Mac OS HFS
+// date in big-endian byte order. The wall clock of t in its associated location
+// is written as is, so t should be converted with t.In in advance if necessary.
+// Fractions of a second are truncated. ErrTimeOutOfRange is returned if the
+// wall clock is not in the range from 1904-01-01 00:00:00 to 2040-02-06
+// 06:28:15.
+func WriteHFSTime(w io.Writer, t time.Time) error {
+	b, err := AppendHFSTime(nil, t)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// LeapSecond represents an entry of a LeapSecondTable.
+type LeapSecond struct {
+	// Time is the UTC time from which Offset is effective.
+	Time time.Time
+
+	// Offset is the difference between TAI and UTC, TAI - UTC, in seconds.
+	Offset int
+}
+
+// LeapSecondTable is a list of leap seconds, used to convert between UTC and
+// the time scales that do not have leap seconds, such as TAI and GPS time. The
+// entries must be sorted in ascending order of Time. The offset of the first
+// entry is also used for times before it.
+type LeapSecondTable []LeapSecond
+
+// DefaultLeapSecondTable is the LeapSecondTable used when nil is passed to the
+// functions that take a LeapSecondTable. It contains all the leap seconds
+// announced by the IERS as of the last update of this package. Applications
+// that must handle leap seconds announced later can pass their own table, or
+// can append entries to this variable at initialization.
+var DefaultLeapSecondTable = LeapSecondTable{
+	{time.Date(1972, 1, 1, 0, 0, 0, 0, time.UTC), 10},
+	{time.Date(1972, 7, 1, 0, 0, 0, 0, time.UTC), 11},
+	{time.Date(1973, 1, 1, 0, 0, 0, 0, time.UTC), 12},
+	{time.Date(1974, 1, 1, 0, 0, 0, 0, time.UTC), 13},
+	{time.Date(1975, 1, 1, 0, 0, 0, 0, time.UTC), 14},
+	{time.Date(1976, 1, 1, 0, 0, 0, 0, time.UTC), 15},
+	{time.Date(1977, 1, 1, 0, 0, 0, 0, time.UTC), 16},
+	{time.Date(1978, 1, 1, 0, 0, 0, 0, time.UTC), 17},
+	{time.Date(1979, 1, 1, 0, 0, 0, 0, time.UTC), 18},
+	{time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC), 19},
+	{time.Date(1981, 7, 1, 0, 0, 0, 0, time.UTC), 20},
+	{time.Date(1982, 7, 1, 0, 0, 0, 0, time.UTC), 21},
+	{time.Date(1983, 7, 1, 0, 0, 0, 0, time.UTC), 22},
+	{time.Date(1985, 7, 1, 0, 0, 0, 0, time.UTC), 23},
+	{time.Date(1988, 1, 1, 0, 0, 0, 0, time.UTC), 24},
+	{time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), 25},
+	{time.Date(1991, 1, 1, 0, 0, 0, 0, time.UTC), 26},
+	{time.Date(1992, 7, 1, 0, 0, 0, 0, time.UTC), 27},
+	{time.Date(1993, 7, 1, 0, 0, 0, 0, time.UTC), 28},
+	{time.Date(1994, 7, 1, 0, 0, 0, 0, time.UTC), 29},
+	{time.Date(1996, 1, 1, 0, 0, 0, 0, time.UTC), 30},
+	{time.Date(1997, 7, 1, 0, 0, 0, 0, time.UTC), 31},
+	{time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC), 32},
+	{time.Date(2006, 1, 1, 0, 0, 0, 0, time.UTC), 33},
+	{time.Date(2009, 1, 1, 0, 0, 0, 0, time.UTC), 34},
+	{time.Date(2012, 7, 1, 0, 0, 0, 0, time.UTC), 35},
+	{time.Date(2015, 7, 1, 0, 0, 0, 0, time.UTC), 36},
+	{time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), 37},
+}
+
+// toTAI returns the number of TAI seconds elapsed since the UNIX epoch for the
+// UNIX time sec, that is sec plus TAI - UTC at that time. If tbl is nil,
+// DefaultLeapSecondTable is used.
+func (tbl LeapSecondTable) toTAI(sec int64) int64 {
+	if tbl == nil {
+		tbl = DefaultLeapSecondTable
+	}
+	off := 0
+	for i, ls := range tbl {
+		if i != 0 && sec < ls.Time.Unix() {
+			break
+		}
+		off = ls.Offset
+	}
+	return sec + int64(off)
+}
+
+// fromTAI is the inverse of toTAI. The TAI second inserted as a leap second is
+// converted to the UNIX time of the first second after it.
+func (tbl LeapSecondTable) fromTAI(tai int64) int64 {
+	if tbl == nil {
+		tbl = DefaultLeapSecondTable
+	}
+	off := 0
+	for i, ls := range tbl {
+		if i != 0 && tai < ls.Time.Unix()+int64(ls.Offset) {
+			break
+		}
+		off = ls.Offset
+	}
+	return tai - int64(off)
+}
+
+const (
+	// gpsEpoch is the UNIX time of the GPS epoch, Jan 6, 1980 UTC.
+	gpsEpoch = 315964800
+
+	// gpsTAIOffset is the constant difference between TAI and GPS time.
+	gpsTAIOffset = 19
+
+	// secondsPerWeek is the number of seconds in a week.
+	secondsPerWeek = 7 * 24 * 60 * 60
+)
+
+// ReadGPSTimeBE reads 6 bytes of a GPS time, a 16-bit unsigned week number
+// since the GPS epoch, Jan 6, 1980 UTC, followed by a 32-bit unsigned number of
+// seconds elapsed since the beginning of the week, both in big-endian byte
+// order, from r and returns the UTC time it represents. The week number is not
+// taken modulo 1024, unlike the 10-bit week number broadcast by the satellites.
+// Since GPS time does not have leap seconds, it is converted to UTC using tbl.
+// If tbl is nil, DefaultLeapSecondTable is used. ErrInvalidTime is returned if
+// the seconds of week is 604800 or more.
+func ReadGPSTimeBE(r io.Reader, tbl LeapSecondTable) (time.Time, error) {
+	b, err := readN(r, 6)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, _, err := DecodeGPSTimeBE(b, tbl)
+	return t, err
+}
+
+// WriteGPSTimeBE writes 6 bytes to w that represent t as a GPS time in
+// big-endian byte order. See ReadGPSTimeBE for the details of the format.
+// Fractions of a second are truncated toward the past. The written bytes do not
+// depend on the location associated with t. ErrTimeOutOfRange is returned if t
+// is before the GPS epoch or the week number exceeds 65535.
+func WriteGPSTimeBE(w io.Writer, t time.Time, tbl LeapSecondTable) error {
+	b, err := AppendGPSTimeBE(nil, t, tbl)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadGPSTimeLE is identical to ReadGPSTimeBE except that it reads the fields
+// in little-endian byte order.
+func ReadGPSTimeLE(r io.Reader, tbl LeapSecondTable) (time.Time, error) {
+	b, err := readN(r, 6)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, _, err := DecodeGPSTimeLE(b, tbl)
+	return t, err
+}
+
+// WriteGPSTimeLE is identical to WriteGPSTimeBE except that it writes the
+// fields in little-endian byte order.
+func WriteGPSTimeLE(w io.Writer, t time.Time, tbl LeapSecondTable) error {
+	b, err := AppendGPSTimeLE(nil, t, tbl)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// gpsTime returns the UTC time represented by the GPS week and seconds of week.
+func gpsTime(week uint16, sow uint32, tbl LeapSecondTable) (time.Time, error) {
+	if secondsPerWeek <= sow {
+		return time.Time{}, fmt.Errorf("%w: GPS seconds of week %d", ErrInvalidTime, sow)
+	}
+	tai := gpsEpoch + gpsTAIOffset + int64(week)*secondsPerWeek + int64(sow)
+	return time.Unix(tbl.fromTAI(tai), 0).UTC(), nil
+}
+
+// gpsValue returns the GPS week and seconds of week that represent t.
+func gpsValue(t time.Time, tbl LeapSecondTable) (uint16, uint32, error) {
+	v := tbl.toTAI(t.Unix()) - gpsTAIOffset - gpsEpoch
+	if v < 0 || math.MaxUint16 < v/secondsPerWeek {
+		return 0, 0, fmt.Errorf("%w: %v", ErrTimeOutOfRange, t)
+	}
+	return uint16(v / secondsPerWeek), uint32(v % secondsPerWeek), nil
+}
+
+// tai64Base is the TAI64 label of Jan 1, 1970 TAI.
+const tai64Base = 1 << 62
+
+// ReadTAI64 reads 8 bytes of a TAI64 label, 2^62 plus the number of TAI
+// seconds elapsed since Jan 1, 1970 TAI in big-endian byte order, from r and
+// returns the UTC time it represents. The TAI seconds are converted to UTC
+// using tbl. If tbl is nil, DefaultLeapSecondTable is used. ErrInvalidTime is
+// returned if the label is 2^63 or more, which is reserved.
+//
+// Note that the tai64n and tai64nlocal programs of daemontools assume a
+// constant difference of 10 seconds between TAI and UTC, ignoring leap seconds.
+// To interoperate with them, pass LeapSecondTable{{Offset: 10}} as tbl.
+func ReadTAI64(r io.Reader, tbl LeapSecondTable) (time.Time, error) {
+	b, err := readN(r, 8)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, _, err := DecodeTAI64(b, tbl)
+	return t, err
+}
+
+// WriteTAI64 writes 8 bytes to w that represent t as a TAI64 label. See
+// ReadTAI64 for the details of the format. Fractions of a second are truncated
+// toward the past. The written bytes do not depend on the location associated
+// with t. ErrTimeOutOfRange is returned if t does not fit in the format.
+func WriteTAI64(w io.Writer, t time.Time, tbl LeapSecondTable) error {
+	b, err := AppendTAI64(nil, t, tbl)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadTAI64N reads 12 bytes of a TAI64N label, a TAI64 label followed by a
+// 32-bit unsigned number of nanoseconds in big-endian byte order, from r and
+// returns the UTC time it represents. See ReadTAI64 for the details of the
+// conversion. ErrInvalidTime is returned if the label is 2^63 or more, or the
+// nanoseconds is 10^9 or more.
+func ReadTAI64N(r io.Reader, tbl LeapSecondTable) (time.Time, error) {
+	b, err := readN(r, 12)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, _, err := DecodeTAI64N(b, tbl)
+	return t, err
+}
+
+// WriteTAI64N writes 12 bytes to w that represent t as a TAI64N label. See
+// ReadTAI64N for the details of the format. The written bytes do not depend on
+// the location associated with t. ErrTimeOutOfRange is returned if t does not
+// fit in the format.
+func WriteTAI64N(w io.Writer, t time.Time, tbl LeapSecondTable) error {
+	b, err := AppendTAI64N(nil, t, tbl)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// tai64Time returns the UTC time represented by the TAI64 label v and the
+// nanoseconds nsec.
+func tai64Time(v uint64, nsec uint32, tbl LeapSecondTable) (time.Time, error) {
+	if 1<<63 <= v || 1e9 <= nsec {
+		return time.Time{}, fmt.Errorf("%w: TAI64 label %#016x, %d ns", ErrInvalidTime, v, nsec)
+	}
+	tai := int64(v) - tai64Base
+	return time.Unix(tbl.fromTAI(tai), int64(nsec)).UTC(), nil
+}
+
+// tai64Value returns the TAI64 label that represents t.
+func tai64Value(t time.Time, tbl LeapSecondTable) (uint64, error) {
+	sec := t.Unix()
+	if sec < -tai64Base || tai64Base <= sec {
+		return 0, fmt.Errorf("%w: %v", ErrTimeOutOfRange, t)
+	}
+	tai := tbl.toTAI(sec)
+	if tai < -tai64Base || tai64Base <= tai {
+		return 0, fmt.Errorf("%w: %v", ErrTimeOutOfRange, t)
+	}
+	return uint64(tai + tai64Base), nil
+}
+
+// ReadDurationBE reads size bytes from r, interprets them as an unsigned
+// integer in big-endian byte order that represents a duration in units of
+// unit, and returns it as a time.Duration. For example, a 32-bit number of
+// milliseconds can be read with unit time.Millisecond and size 4. The size must
+// be 1, 2, 4 or 8, otherwise ErrInvalidSize is returned. ErrValueOutOfRange is
+// returned if unit is not positive. ErrOverflow is returned if the duration
+// read does not fit in time.Duration.
+func ReadDurationBE(r io.Reader, unit time.Duration, size int) (time.Duration, error) {
+	if err := checkDurationFormat(unit, size); err != nil {
+		return 0, err
+	}
+	b, err := readN(r, size)
+	if err != nil {
+		return 0, err
+	}
+	d, _, err := DecodeDurationBE(b, unit, size)
+	return d, err
+}
+
+// WriteDurationBE writes size bytes to w that represent d as an unsigned
+// integer in units of unit in big-endian byte order. Fractions smaller than unit
+// are truncated. The size must be 1, 2, 4 or 8, otherwise ErrInvalidSize is
+// returned. ErrValueOutOfRange is returned if unit is not positive, d is
+// negative, or d does not fit in size bytes.
+func WriteDurationBE(w io.Writer, d, unit time.Duration, size int) error {
+	b, err := AppendDurationBE(nil, d, unit, size)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// ReadDurationLE is identical to ReadDurationBE except that it reads the value
+// in little-endian byte order.
+func ReadDurationLE(r io.Reader, unit time.Duration, size int) (time.Duration, error) {
+	if err := checkDurationFormat(unit, size); err != nil {
+		return 0, err
+	}
+	b, err := readN(r, size)
+	if err != nil {
+		return 0, err
+	}
+	d, _, err := DecodeDurationLE(b, unit, size)
+	return d, err
+}
+
+// WriteDurationLE is identical to WriteDurationBE except that it writes the
+// value in little-endian byte order.
+func WriteDurationLE(w io.Writer, d, unit time.Duration, size int) error {
+	b, err := AppendDurationLE(nil, d, unit, size)
+	if err != nil {
+		return err
+	}
+	return write(w, b)
+}
+
+// checkDurationFormat returns an error if unit or size is not supported.
+func checkDurationFormat(unit time.Duration, size int) error {
+	switch {
+	case size != 1 && size != 2 && size != 4 && size != 8:
+		return fmt.Errorf("%w: %d bytes for duration", ErrInvalidSize, size)
+	case unit <= 0:
+		return fmt.Errorf("%w: duration unit %v", ErrValueOutOfRange, unit)
+	}
+	return nil
+}
+
+// ReadDurationSecNanosBE reads 12 bytes from r, a 64-bit signed number of
+// seconds followed by a 32-bit signed number of nanoseconds both in big-endian
+// byte order, and returns the sum of them as a time.Duration. This is the
+// layout of the Duration message of Protocol Buffers. ErrInvalidTime is
+// returned if the nanoseconds is not in the range from -999999999 to 999999999,
+// or has the opposite sign to the seconds. ErrOverflow is returned if the
+// duration read does not fit in time.Duration.
+func ReadDurationSecNanosBE(r io.Reader) (time.Duration, error) {
+	return readDecode(r, 12, DecodeDurationSecNanosBE)
+}
+
+// WriteDurationSecNanosBE writes 12 bytes to w that represent d as a 64-bit
+// signed number of seconds followed by a 32-bit signed number of nanoseconds
+// both in big-endian byte order. For a negative d, both fields are negative or
+// zero. Any time.Duration value can be written.
+func WriteDurationSecNanosBE(w io.Writer, d time.Duration) error {
+	return write(w, AppendDurationSecNanosBE(nil, d))
+}
+
+// ReadDurationSecNanosLE is identical to ReadDurationSecNanosBE except that it
+// reads the fields in little-endian byte order.
+func ReadDurationSecNanosLE(r io.Reader) (time.Duration, error) {
+	return readDecode(r, 12, DecodeDurationSecNanosLE)
+}
+
+// WriteDurationSecNanosLE is identical to WriteDurationSecNanosBE except that
+// it writes the fields in little-endian byte order.
+func WriteDurationSecNanosLE(w io.Writer, d time.Duration) error {
+	return write(w, AppendDurationSecNanosLE(nil, d))
+}
+
+// durationSecNanos returns the duration of sec seconds and nsec nanoseconds.
+func durationSecNanos(sec int64, nsec int32) (time.Duration, error) {
+	switch {
+	case nsec <= -1e9, 1e9 <= nsec, sec < 0 && 0 < nsec, 0 < sec && nsec < 0:
+		return 0, fmt.Errorf("%w: duration %d s, %d ns", ErrInvalidTime, sec, nsec)
+	case sec < math.MinInt64/int64(time.Second), math.MaxInt64/int64(time.Second) < sec:
+		return 0, fmt.Errorf("%w: duration %d s, %d ns", ErrOverflow, sec, nsec)
+	}
+	d := time.Duration(sec) * time.Second
+	if (0 < nsec && math.MaxInt64-d < time.Duration(nsec)) || (nsec < 0 && time.Duration(nsec) < math.MinInt64-d) {
+		return 0, fmt.Errorf("%w: duration %d s, %d ns", ErrOverflow, sec, nsec)
+	}
+	return d + time.Duration(nsec), nil
 }
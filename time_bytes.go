@@ -0,0 +1,817 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+// DecodeUnixTimeUTC32BE is identical to ReadUnixTimeUTC32BE except that it
+// decodes the first 4 bytes of b and returns the time along with the rest of b.
+func DecodeUnixTimeUTC32BE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeUint32BE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return time.Unix(int64(v), 0).UTC(), rest, nil
+}
+
+// DecodeUnixTime32BE is identical to DecodeUnixTimeUTC32BE except that it
+// returns the local time rather than UTC.
+func DecodeUnixTime32BE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeUint32BE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return time.Unix(int64(v), 0).Local(), rest, nil
+}
+
+// AppendUnixTime32BE is identical to WriteUnixTime32BE except that it appends
+// the bytes to dst and returns the extended buffer.
+func AppendUnixTime32BE(dst []byte, t time.Time) []byte {
+	return AppendUint32BE(dst, uint32(t.Unix()))
+}
+
+// AppendUnixTimeUint32BE is identical to WriteUnixTimeUint32BE except that it
+// appends the bytes to dst and returns the extended buffer.
+func AppendUnixTimeUint32BE(dst []byte, t time.Time) ([]byte, error) {
+	v := t.Unix()
+	if v < 0 || math.MaxUint32 < v {
+		return dst, fmt.Errorf("%w: %v", ErrTimeOutOfRange, t)
+	}
+	return AppendUint32BE(dst, uint32(v)), nil
+}
+
+// DecodeUnixTimeUTCInt32BE is identical to ReadUnixTimeUTCInt32BE except that
+// it decodes the first 4 bytes of b and returns the time along with the rest of
+// b.
+func DecodeUnixTimeUTCInt32BE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeInt32BE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return time.Unix(int64(v), 0).UTC(), rest, nil
+}
+
+// DecodeUnixTimeInt32BE is identical to DecodeUnixTimeUTCInt32BE except that it
+// returns the local time rather than UTC.
+func DecodeUnixTimeInt32BE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeInt32BE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return time.Unix(int64(v), 0).Local(), rest, nil
+}
+
+// AppendUnixTimeInt32BE is identical to WriteUnixTimeInt32BE except that it
+// appends the bytes to dst and returns the extended buffer.
+func AppendUnixTimeInt32BE(dst []byte, t time.Time) ([]byte, error) {
+	v := t.Unix()
+	if v < math.MinInt32 || math.MaxInt32 < v {
+		return dst, fmt.Errorf("%w: %v", ErrTimeOutOfRange, t)
+	}
+	return AppendInt32BE(dst, int32(v)), nil
+}
+
+// DecodeUnixTimeUTC32LE is identical to ReadUnixTimeUTC32LE except that it
+// decodes the first 4 bytes of b and returns the time along with the rest of b.
+func DecodeUnixTimeUTC32LE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeUint32LE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return time.Unix(int64(v), 0).UTC(), rest, nil
+}
+
+// DecodeUnixTime32LE is identical to DecodeUnixTimeUTC32LE except that it
+// returns the local time rather than UTC.
+func DecodeUnixTime32LE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeUint32LE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return time.Unix(int64(v), 0).Local(), rest, nil
+}
+
+// AppendUnixTime32LE is identical to WriteUnixTime32LE except that it appends
+// the bytes to dst and returns the extended buffer.
+func AppendUnixTime32LE(dst []byte, t time.Time) []byte {
+	return AppendUint32LE(dst, uint32(t.Unix()))
+}
+
+// AppendUnixTimeUint32LE is identical to WriteUnixTimeUint32LE except that it
+// appends the bytes to dst and returns the extended buffer.
+func AppendUnixTimeUint32LE(dst []byte, t time.Time) ([]byte, error) {
+	v := t.Unix()
+	if v < 0 || math.MaxUint32 < v {
+		return dst, fmt.Errorf("%w: %v", ErrTimeOutOfRange, t)
+	}
+	return AppendUint32LE(dst, uint32(v)), nil
+}
+
+// DecodeUnixTimeUTCInt32LE is identical to ReadUnixTimeUTCInt32LE except that
+// it decodes the first 4 bytes of b and returns the time along with the rest of
+// b.
+func DecodeUnixTimeUTCInt32LE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeInt32LE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return time.Unix(int64(v), 0).UTC(), rest, nil
+}
+
+// DecodeUnixTimeInt32LE is identical to DecodeUnixTimeUTCInt32LE except that it
+// returns the local time rather than UTC.
+func DecodeUnixTimeInt32LE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeInt32LE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return time.Unix(int64(v), 0).Local(), rest, nil
+}
+
+// AppendUnixTimeInt32LE is identical to WriteUnixTimeInt32LE except that it
+// appends the bytes to dst and returns the extended buffer.
+func AppendUnixTimeInt32LE(dst []byte, t time.Time) ([]byte, error) {
+	v := t.Unix()
+	if v < math.MinInt32 || math.MaxInt32 < v {
+		return dst, fmt.Errorf("%w: %v", ErrTimeOutOfRange, t)
+	}
+	return AppendInt32LE(dst, int32(v)), nil
+}
+
+// DecodeUnixTimeUTC64BE is identical to ReadUnixTimeUTC64BE except that it
+// decodes the first 8 bytes of b and returns the time along with the rest of b.
+func DecodeUnixTimeUTC64BE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeInt64BE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return unixTime(v, 1).UTC(), rest, nil
+}
+
+// DecodeUnixTime64BE is identical to DecodeUnixTimeUTC64BE except that it
+// returns the local time rather than UTC.
+func DecodeUnixTime64BE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeInt64BE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return unixTime(v, 1).Local(), rest, nil
+}
+
+// AppendUnixTime64BE is identical to WriteUnixTime64BE except that it appends
+// the bytes to dst and returns the extended buffer.
+func AppendUnixTime64BE(dst []byte, t time.Time) ([]byte, error) {
+	v, err := unixValue(t, 1)
+	if err != nil {
+		return dst, err
+	}
+	return AppendInt64BE(dst, v), nil
+}
+
+// DecodeUnixTimeUTC64LE is identical to ReadUnixTimeUTC64LE except that it
+// decodes the first 8 bytes of b and returns the time along with the rest of b.
+func DecodeUnixTimeUTC64LE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeInt64LE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return unixTime(v, 1).UTC(), rest, nil
+}
+
+// DecodeUnixTime64LE is identical to DecodeUnixTimeUTC64LE except that it
+// returns the local time rather than UTC.
+func DecodeUnixTime64LE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeInt64LE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return unixTime(v, 1).Local(), rest, nil
+}
+
+// AppendUnixTime64LE is identical to WriteUnixTime64LE except that it appends
+// the bytes to dst and returns the extended buffer.
+func AppendUnixTime64LE(dst []byte, t time.Time) ([]byte, error) {
+	v, err := unixValue(t, 1)
+	if err != nil {
+		return dst, err
+	}
+	return AppendInt64LE(dst, v), nil
+}
+
+// DecodeUnixMilliUTC64BE is identical to ReadUnixMilliUTC64BE except that it
+// decodes the first 8 bytes of b and returns the time along with the rest of b.
+func DecodeUnixMilliUTC64BE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeInt64BE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return unixTime(v, 1e3).UTC(), rest, nil
+}
+
+// DecodeUnixMilli64BE is identical to DecodeUnixMilliUTC64BE except that it
+// returns the local time rather than UTC.
+func DecodeUnixMilli64BE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeInt64BE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return unixTime(v, 1e3).Local(), rest, nil
+}
+
+// AppendUnixMilli64BE is identical to WriteUnixMilli64BE except that it appends
+// the bytes to dst and returns the extended buffer.
+func AppendUnixMilli64BE(dst []byte, t time.Time) ([]byte, error) {
+	v, err := unixValue(t, 1e3)
+	if err != nil {
+		return dst, err
+	}
+	return AppendInt64BE(dst, v), nil
+}
+
+// DecodeUnixMilliUTC64LE is identical to ReadUnixMilliUTC64LE except that it
+// decodes the first 8 bytes of b and returns the time along with the rest of b.
+func DecodeUnixMilliUTC64LE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeInt64LE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return unixTime(v, 1e3).UTC(), rest, nil
+}
+
+// DecodeUnixMilli64LE is identical to DecodeUnixMilliUTC64LE except that it
+// returns the local time rather than UTC.
+func DecodeUnixMilli64LE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeInt64LE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return unixTime(v, 1e3).Local(), rest, nil
+}
+
+// AppendUnixMilli64LE is identical to WriteUnixMilli64LE except that it appends
+// the bytes to dst and returns the extended buffer.
+func AppendUnixMilli64LE(dst []byte, t time.Time) ([]byte, error) {
+	v, err := unixValue(t, 1e3)
+	if err != nil {
+		return dst, err
+	}
+	return AppendInt64LE(dst, v), nil
+}
+
+// DecodeUnixMicroUTC64BE is identical to ReadUnixMicroUTC64BE except that it
+// decodes the first 8 bytes of b and returns the time along with the rest of b.
+func DecodeUnixMicroUTC64BE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeInt64BE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return unixTime(v, 1e6).UTC(), rest, nil
+}
+
+// DecodeUnixMicro64BE is identical to DecodeUnixMicroUTC64BE except that it
+// returns the local time rather than UTC.
+func DecodeUnixMicro64BE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeInt64BE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return unixTime(v, 1e6).Local(), rest, nil
+}
+
+// AppendUnixMicro64BE is identical to WriteUnixMicro64BE except that it appends
+// the bytes to dst and returns the extended buffer.
+func AppendUnixMicro64BE(dst []byte, t time.Time) ([]byte, error) {
+	v, err := unixValue(t, 1e6)
+	if err != nil {
+		return dst, err
+	}
+	return AppendInt64BE(dst, v), nil
+}
+
+// DecodeUnixMicroUTC64LE is identical to ReadUnixMicroUTC64LE except that it
+// decodes the first 8 bytes of b and returns the time along with the rest of b.
+func DecodeUnixMicroUTC64LE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeInt64LE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return unixTime(v, 1e6).UTC(), rest, nil
+}
+
+// DecodeUnixMicro64LE is identical to DecodeUnixMicroUTC64LE except that it
+// returns the local time rather than UTC.
+func DecodeUnixMicro64LE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeInt64LE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return unixTime(v, 1e6).Local(), rest, nil
+}
+
+// AppendUnixMicro64LE is identical to WriteUnixMicro64LE except that it appends
+// the bytes to dst and returns the extended buffer.
+func AppendUnixMicro64LE(dst []byte, t time.Time) ([]byte, error) {
+	v, err := unixValue(t, 1e6)
+	if err != nil {
+		return dst, err
+	}
+	return AppendInt64LE(dst, v), nil
+}
+
+// DecodeUnixNanoUTC64BE is identical to ReadUnixNanoUTC64BE except that it
+// decodes the first 8 bytes of b and returns the time along with the rest of b.
+func DecodeUnixNanoUTC64BE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeInt64BE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return unixTime(v, 1e9).UTC(), rest, nil
+}
+
+// DecodeUnixNano64BE is identical to DecodeUnixNanoUTC64BE except that it
+// returns the local time rather than UTC.
+func DecodeUnixNano64BE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeInt64BE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return unixTime(v, 1e9).Local(), rest, nil
+}
+
+// AppendUnixNano64BE is identical to WriteUnixNano64BE except that it appends
+// the bytes to dst and returns the extended buffer.
+func AppendUnixNano64BE(dst []byte, t time.Time) ([]byte, error) {
+	v, err := unixValue(t, 1e9)
+	if err != nil {
+		return dst, err
+	}
+	return AppendInt64BE(dst, v), nil
+}
+
+// DecodeUnixNanoUTC64LE is identical to ReadUnixNanoUTC64LE except that it
+// decodes the first 8 bytes of b and returns the time along with the rest of b.
+func DecodeUnixNanoUTC64LE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeInt64LE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return unixTime(v, 1e9).UTC(), rest, nil
+}
+
+// DecodeUnixNano64LE is identical to DecodeUnixNanoUTC64LE except that it
+// returns the local time rather than UTC.
+func DecodeUnixNano64LE(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeInt64LE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return unixTime(v, 1e9).Local(), rest, nil
+}
+
+// AppendUnixNano64LE is identical to WriteUnixNano64LE except that it appends
+// the bytes to dst and returns the extended buffer.
+func AppendUnixNano64LE(dst []byte, t time.Time) ([]byte, error) {
+	v, err := unixValue(t, 1e9)
+	if err != nil {
+		return dst, err
+	}
+	return AppendInt64LE(dst, v), nil
+}
+
+// DecodeNTPTimestamp is identical to ReadNTPTimestamp except that it decodes
+// the first 8 bytes of b and returns the time along with the rest of b.
+func DecodeNTPTimestamp(b []byte) (time.Time, []byte, error) {
+	h, rest, err := decodeN(b, 8)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	sec := int64(binary.BigEndian.Uint32(h)) - ntpEpochOffset
+	if sec < -ntpEpochOffset+1<<31 {
+		sec += 1 << 32
+	}
+	frac := uint64(binary.BigEndian.Uint32(h[4:]))
+	nsec := (frac*1e9 + 1<<31) >> 32
+	return time.Unix(sec, int64(nsec)).UTC(), rest, nil
+}
+
+// AppendNTPTimestamp is identical to WriteNTPTimestamp except that it appends
+// the bytes to dst and returns the extended buffer.
+func AppendNTPTimestamp(dst []byte, t time.Time) ([]byte, error) {
+	sec := t.Unix()
+	if sec < -ntpEpochOffset+1<<31 || -ntpEpochOffset+1<<32+1<<31 <= sec {
+		return dst, fmt.Errorf("%w: %v", ErrTimeOutOfRange, t)
+	}
+	frac := (uint64(t.Nanosecond())<<32 + 5e8) / 1e9
+	dst = AppendUint32BE(dst, uint32(sec+ntpEpochOffset))
+	return AppendUint32BE(dst, uint32(frac)), nil
+}
+
+// DecodeNTPShort is identical to ReadNTPShort except that it decodes the first
+// 4 bytes of b and returns the duration along with the rest of b.
+func DecodeNTPShort(b []byte) (time.Duration, []byte, error) {
+	v, rest, err := DecodeUint32BE(b)
+	if err != nil {
+		return 0, b, err
+	}
+	return time.Duration((uint64(v)*1e9 + 1<<15) >> 16), rest, nil
+}
+
+// AppendNTPShort is identical to WriteNTPShort except that it appends the bytes
+// to dst and returns the extended buffer.
+func AppendNTPShort(dst []byte, d time.Duration) ([]byte, error) {
+	if d < 0 || 1<<16*time.Second <= d {
+		return dst, fmt.Errorf("%w: %v in NTP short format", ErrValueOutOfRange, d)
+	}
+	v := (uint64(d)<<16 + 5e8) / 1e9
+	if math.MaxUint32 < v {
+		return dst, fmt.Errorf("%w: %v in NTP short format", ErrValueOutOfRange, d)
+	}
+	return AppendUint32BE(dst, uint32(v)), nil
+}
+
+// DecodeWindowsFileTime is identical to ReadWindowsFileTime except that it
+// decodes the first 8 bytes of b and returns the time along with the rest of b.
+func DecodeWindowsFileTime(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeUint64LE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	sec := int64(v/1e7) - fileTimeEpochOffset
+	return time.Unix(sec, int64(v%1e7)*100).UTC(), rest, nil
+}
+
+// AppendWindowsFileTime is identical to WriteWindowsFileTime except that it
+// appends the bytes to dst and returns the extended buffer.
+func AppendWindowsFileTime(dst []byte, t time.Time) ([]byte, error) {
+	sec := t.Unix() + fileTimeEpochOffset
+	frac := uint64(t.Nanosecond() / 100)
+	if sec < 0 || (math.MaxUint64-frac)/1e7 < uint64(sec) {
+		return dst, fmt.Errorf("%w: %v", ErrTimeOutOfRange, t)
+	}
+	return AppendUint64LE(dst, uint64(sec)*1e7+frac), nil
+}
+
+// DecodeDotNetDateTime is identical to ReadDotNetDateTime except that it
+// decodes the first 8 bytes of b and returns the time and the Kind along with
+// the rest of b.
+func DecodeDotNetDateTime(b []byte) (time.Time, DotNetKind, []byte, error) {
+	v, rest, err := DecodeUint64LE(b)
+	if err != nil {
+		return time.Time{}, 0, b, err
+	}
+	kind := DotNetKind(v >> 62)
+	ticks := v & (1<<62 - 1)
+	if dotNetMaxTicks < ticks {
+		return time.Time{}, 0, b, fmt.Errorf("%w: DateTime ticks %d", ErrInvalidTime, ticks)
+	}
+	t := time.Unix(int64(ticks/1e7)-dotNetEpochOffset, int64(ticks%1e7)*100).UTC()
+	switch kind {
+	case DotNetKindUnspecified, DotNetKindUTC:
+		return t, kind, rest, nil
+	}
+	y, mo, d := t.Date()
+	h, mi, s := t.Clock()
+	return time.Date(y, mo, d, h, mi, s, t.Nanosecond(), time.Local), DotNetKindLocal, rest, nil
+}
+
+// AppendDotNetDateTime is identical to WriteDotNetDateTime except that it
+// appends the bytes to dst and returns the extended buffer.
+func AppendDotNetDateTime(dst []byte, t time.Time, kind DotNetKind) ([]byte, error) {
+	switch kind {
+	case DotNetKindUnspecified:
+	case DotNetKindUTC:
+		t = t.UTC()
+	case DotNetKindLocal:
+		t = t.Local()
+	default:
+		return dst, fmt.Errorf("%w: DateTime kind %d", ErrValueOutOfRange, kind)
+	}
+	y, mo, d := t.Date()
+	h, mi, s := t.Clock()
+	sec := time.Date(y, mo, d, h, mi, s, 0, time.UTC).Unix() + dotNetEpochOffset
+	if sec < 0 || dotNetMaxTicks/10000000 < sec {
+		return dst, fmt.Errorf("%w: %v", ErrTimeOutOfRange, t)
+	}
+	ticks := uint64(sec)*1e7 + uint64(t.Nanosecond()/100)
+	return AppendUint64LE(dst, uint64(kind)<<62|ticks), nil
+}
+
+// DecodeDOSDateTime is identical to ReadDOSDateTime except that it decodes the
+// first 4 bytes of b and returns the time along with the rest of b.
+func DecodeDOSDateTime(b []byte, loc *time.Location) (time.Time, []byte, error) {
+	h, rest, err := decodeN(b, 4)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	t, err := dosTime(binary.LittleEndian.Uint16(h), binary.LittleEndian.Uint16(h[2:]), loc)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return t, rest, nil
+}
+
+// DecodeDOSTimeDate is identical to DecodeDOSDateTime except that it decodes
+// the time before the date.
+func DecodeDOSTimeDate(b []byte, loc *time.Location) (time.Time, []byte, error) {
+	h, rest, err := decodeN(b, 4)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	t, err := dosTime(binary.LittleEndian.Uint16(h[2:]), binary.LittleEndian.Uint16(h), loc)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return t, rest, nil
+}
+
+// AppendDOSDateTime is identical to WriteDOSDateTime except that it appends the
+// bytes to dst and returns the extended buffer.
+func AppendDOSDateTime(dst []byte, t time.Time) ([]byte, error) {
+	d, tm, err := dosValue(t)
+	if err != nil {
+		return dst, err
+	}
+	return AppendUint16LE(AppendUint16LE(dst, d), tm), nil
+}
+
+// AppendDOSTimeDate is identical to AppendDOSDateTime except that it appends
+// the time before the date.
+func AppendDOSTimeDate(dst []byte, t time.Time) ([]byte, error) {
+	d, tm, err := dosValue(t)
+	if err != nil {
+		return dst, err
+	}
+	return AppendUint16LE(AppendUint16LE(dst, tm), d), nil
+}
+
+// DecodeHFSPlusTime is identical to ReadHFSPlusTime except that it decodes the
+// first 4 bytes of b and returns the time along with the rest of b.
+func DecodeHFSPlusTime(b []byte) (time.Time, []byte, error) {
+	v, rest, err := DecodeUint32BE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return time.Unix(int64(v)-hfsEpochOffset, 0).UTC(), rest, nil
+}
+
+// AppendHFSPlusTime is identical to WriteHFSPlusTime except that it appends the
+// bytes to dst and returns the extended buffer.
+func AppendHFSPlusTime(dst []byte, t time.Time) ([]byte, error) {
+	v := t.Unix() + hfsEpochOffset
+	if v < 0 || math.MaxUint32 < v {
+		return dst, fmt.Errorf("%w: %v", ErrTimeOutOfRange, t)
+	}
+	return AppendUint32BE(dst, uint32(v)), nil
+}
+
+// DecodeHFSTime is identical to ReadHFSTime except that it decodes the first 4
+// bytes of b and returns the time along with the rest of b.
+func DecodeHFSTime(b []byte, loc *time.Location) (time.Time, []byte, error) {
+	v, rest, err := DecodeUint32BE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	t := time.Unix(int64(v)-hfsEpochOffset, 0).UTC()
+	y, mo, d := t.Date()
+	h, mi, s := t.Clock()
+	return time.Date(y, mo, d, h, mi, s, 0, loc), rest, nil
+}
+
+// AppendHFSTime is identical to WriteHFSTime except that it appends the bytes
+// to dst and returns the extended buffer.
+func AppendHFSTime(dst []byte, t time.Time) ([]byte, error) {
+	y, mo, d := t.Date()
+	h, mi, s := t.Clock()
+	v := time.Date(y, mo, d, h, mi, s, 0, time.UTC).Unix() + hfsEpochOffset
+	if v < 0 || math.MaxUint32 < v {
+		return dst, fmt.Errorf("%w: %v", ErrTimeOutOfRange, t)
+	}
+	return AppendUint32BE(dst, uint32(v)), nil
+}
+
+// DecodeGPSTimeBE is identical to ReadGPSTimeBE except that it decodes the
+// first 6 bytes of b and returns the time along with the rest of b.
+func DecodeGPSTimeBE(b []byte, tbl LeapSecondTable) (time.Time, []byte, error) {
+	h, rest, err := decodeN(b, 6)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	t, err := gpsTime(binary.BigEndian.Uint16(h), binary.BigEndian.Uint32(h[2:]), tbl)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return t, rest, nil
+}
+
+// AppendGPSTimeBE is identical to WriteGPSTimeBE except that it appends the
+// bytes to dst and returns the extended buffer.
+func AppendGPSTimeBE(dst []byte, t time.Time, tbl LeapSecondTable) ([]byte, error) {
+	week, sow, err := gpsValue(t, tbl)
+	if err != nil {
+		return dst, err
+	}
+	return AppendUint32BE(AppendUint16BE(dst, week), sow), nil
+}
+
+// DecodeGPSTimeLE is identical to ReadGPSTimeLE except that it decodes the
+// first 6 bytes of b and returns the time along with the rest of b.
+func DecodeGPSTimeLE(b []byte, tbl LeapSecondTable) (time.Time, []byte, error) {
+	h, rest, err := decodeN(b, 6)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	t, err := gpsTime(binary.LittleEndian.Uint16(h), binary.LittleEndian.Uint32(h[2:]), tbl)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return t, rest, nil
+}
+
+// AppendGPSTimeLE is identical to WriteGPSTimeLE except that it appends the
+// bytes to dst and returns the extended buffer.
+func AppendGPSTimeLE(dst []byte, t time.Time, tbl LeapSecondTable) ([]byte, error) {
+	week, sow, err := gpsValue(t, tbl)
+	if err != nil {
+		return dst, err
+	}
+	return AppendUint32LE(AppendUint16LE(dst, week), sow), nil
+}
+
+// DecodeTAI64 is identical to ReadTAI64 except that it decodes the first 8
+// bytes of b and returns the time along with the rest of b.
+func DecodeTAI64(b []byte, tbl LeapSecondTable) (time.Time, []byte, error) {
+	v, rest, err := DecodeUint64BE(b)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	t, err := tai64Time(v, 0, tbl)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return t, rest, nil
+}
+
+// AppendTAI64 is identical to WriteTAI64 except that it appends the bytes to
+// dst and returns the extended buffer.
+func AppendTAI64(dst []byte, t time.Time, tbl LeapSecondTable) ([]byte, error) {
+	v, err := tai64Value(t, tbl)
+	if err != nil {
+		return dst, err
+	}
+	return AppendUint64BE(dst, v), nil
+}
+
+// DecodeTAI64N is identical to ReadTAI64N except that it decodes the first 12
+// bytes of b and returns the time along with the rest of b.
+func DecodeTAI64N(b []byte, tbl LeapSecondTable) (time.Time, []byte, error) {
+	h, rest, err := decodeN(b, 12)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	t, err := tai64Time(binary.BigEndian.Uint64(h), binary.BigEndian.Uint32(h[8:]), tbl)
+	if err != nil {
+		return time.Time{}, b, err
+	}
+	return t, rest, nil
+}
+
+// AppendTAI64N is identical to WriteTAI64N except that it appends the bytes to
+// dst and returns the extended buffer.
+func AppendTAI64N(dst []byte, t time.Time, tbl LeapSecondTable) ([]byte, error) {
+	v, err := tai64Value(t, tbl)
+	if err != nil {
+		return dst, err
+	}
+	return AppendUint32BE(AppendUint64BE(dst, v), uint32(t.Nanosecond())), nil
+}
+
+// DecodeDurationBE is identical to ReadDurationBE except that it decodes the
+// first size bytes of b and returns the duration along with the rest of b.
+func DecodeDurationBE(b []byte, unit time.Duration, size int) (time.Duration, []byte, error) {
+	return decodeDuration(b, unit, size, binary.BigEndian)
+}
+
+// AppendDurationBE is identical to WriteDurationBE except that it appends the
+// bytes to dst and returns the extended buffer.
+func AppendDurationBE(dst []byte, d, unit time.Duration, size int) ([]byte, error) {
+	return appendDuration(dst, d, unit, size, binary.BigEndian)
+}
+
+// DecodeDurationLE is identical to ReadDurationLE except that it decodes the
+// first size bytes of b and returns the duration along with the rest of b.
+func DecodeDurationLE(b []byte, unit time.Duration, size int) (time.Duration, []byte, error) {
+	return decodeDuration(b, unit, size, binary.LittleEndian)
+}
+
+// AppendDurationLE is identical to WriteDurationLE except that it appends the
+// bytes to dst and returns the extended buffer.
+func AppendDurationLE(dst []byte, d, unit time.Duration, size int) ([]byte, error) {
+	return appendDuration(dst, d, unit, size, binary.LittleEndian)
+}
+
+// decodeDuration decodes a duration of size bytes in order at the beginning of
+// b.
+func decodeDuration(b []byte, unit time.Duration, size int, order binary.ByteOrder) (time.Duration, []byte, error) {
+	if err := checkDurationFormat(unit, size); err != nil {
+		return 0, b, err
+	}
+	h, rest, err := decodeN(b, size)
+	if err != nil {
+		return 0, b, err
+	}
+	var v uint64
+	switch size {
+	case 1:
+		v = uint64(h[0])
+	case 2:
+		v = uint64(order.Uint16(h))
+	case 4:
+		v = uint64(order.Uint32(h))
+	default:
+		v = order.Uint64(h)
+	}
+	if uint64(math.MaxInt64/unit) < v {
+		return 0, b, fmt.Errorf("%w: %d units of %v", ErrOverflow, v, unit)
+	}
+	return time.Duration(v) * unit, rest, nil
+}
+
+// appendDuration appends d as a duration of size bytes in order to dst.
+func appendDuration(dst []byte, d, unit time.Duration, size int, order binary.ByteOrder) ([]byte, error) {
+	if err := checkDurationFormat(unit, size); err != nil {
+		return dst, err
+	}
+	if d < 0 {
+		return dst, fmt.Errorf("%w: negative duration %v", ErrValueOutOfRange, d)
+	}
+	v := uint64(d / unit)
+	if size < 8 && v>>(8*size) != 0 {
+		return dst, fmt.Errorf("%w: %v in %d-byte units of %v", ErrValueOutOfRange, d, size, unit)
+	}
+	n := len(dst)
+	dst = append(dst, make([]byte, size)...)
+	b := dst[n:]
+	switch size {
+	case 1:
+		b[0] = byte(v)
+	case 2:
+		order.PutUint16(b, uint16(v))
+	case 4:
+		order.PutUint32(b, uint32(v))
+	default:
+		order.PutUint64(b, v)
+	}
+	return dst, nil
+}
+
+// DecodeDurationSecNanosBE is identical to ReadDurationSecNanosBE except that
+// it decodes the first 12 bytes of b and returns the duration along with the
+// rest of b.
+func DecodeDurationSecNanosBE(b []byte) (time.Duration, []byte, error) {
+	h, rest, err := decodeN(b, 12)
+	if err != nil {
+		return 0, b, err
+	}
+	d, err := durationSecNanos(int64(binary.BigEndian.Uint64(h)), int32(binary.BigEndian.Uint32(h[8:])))
+	if err != nil {
+		return 0, b, err
+	}
+	return d, rest, nil
+}
+
+// AppendDurationSecNanosBE is identical to WriteDurationSecNanosBE except that
+// it appends the bytes to dst and returns the extended buffer.
+func AppendDurationSecNanosBE(dst []byte, d time.Duration) []byte {
+	return AppendInt32BE(AppendInt64BE(dst, int64(d/time.Second)), int32(d%time.Second))
+}
+
+// DecodeDurationSecNanosLE is identical to ReadDurationSecNanosLE except that
+// it decodes the first 12 bytes of b and returns the duration along with the
+// rest of b.
+func DecodeDurationSecNanosLE(b []byte) (time.Duration, []byte, error) {
+	h, rest, err := decodeN(b, 12)
+	if err != nil {
+		return 0, b, err
+	}
+	d, err := durationSecNanos(int64(binary.LittleEndian.Uint64(h)), int32(binary.LittleEndian.Uint32(h[8:])))
+	if err != nil {
+		return 0, b, err
+	}
+	return d, rest, nil
+}
+
+// AppendDurationSecNanosLE is identical to WriteDurationSecNanosLE except that
+// it appends the bytes to dst and returns the extended buffer.
+func AppendDurationSecNanosLE(dst []byte, d time.Duration) []byte {
+	return AppendInt32LE(AppendInt64LE(dst, int64(d/time.Second)), int32(d%time.Second))
+}
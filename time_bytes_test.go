@@ -0,0 +1,115 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func TestAppendDecodeTime(t *testing.T) {
+	tm := time.Date(2012, 5, 20, 23, 53, 54, 123456789, time.UTC)
+	sec := tm.Truncate(time.Second)
+
+	testAppendDecode(t, "UnixTime32BE", sec, "4fb98412", noErr(typeio.AppendUnixTime32BE), typeio.DecodeUnixTimeUTC32BE)
+	testAppendDecode(t, "UnixTime32LE", sec, "1284b94f", noErr(typeio.AppendUnixTime32LE), typeio.DecodeUnixTime32LE)
+	testAppendDecode(t, "UnixTimeUint32BE", sec, "4fb98412", typeio.AppendUnixTimeUint32BE, typeio.DecodeUnixTime32BE)
+	testAppendDecode(t, "UnixTimeUint32LE", sec, "1284b94f", typeio.AppendUnixTimeUint32LE, typeio.DecodeUnixTimeUTC32LE)
+	testAppendDecode(t, "UnixTimeInt32BE", sec, "4fb98412", typeio.AppendUnixTimeInt32BE, typeio.DecodeUnixTimeUTCInt32BE)
+	testAppendDecode(t, "UnixTimeInt32LE", sec, "1284b94f", typeio.AppendUnixTimeInt32LE, typeio.DecodeUnixTimeInt32LE)
+	testAppendDecode(t, "UnixTime64BE", sec, "000000004fb98412", typeio.AppendUnixTime64BE, typeio.DecodeUnixTimeUTC64BE)
+	testAppendDecode(t, "UnixTime64LE", sec, "1284b94f00000000", typeio.AppendUnixTime64LE, typeio.DecodeUnixTime64LE)
+	testAppendDecode(t, "UnixMilli64LE", tm.Truncate(time.Millisecond), "cbe6ab6c37010000", typeio.AppendUnixMilli64LE, typeio.DecodeUnixMilliUTC64LE)
+	testAppendDecode(t, "UnixMilli64BE", tm.Truncate(time.Millisecond), "000001376cabe6cb", typeio.AppendUnixMilli64BE, typeio.DecodeUnixMilli64BE)
+	testAppendDecode(t, "UnixMicro64BE", tm.Truncate(time.Microsecond), "0004c0807f7d8ac0", typeio.AppendUnixMicro64BE, typeio.DecodeUnixMicroUTC64BE)
+	testAppendDecode(t, "UnixMicro64LE", tm.Truncate(time.Microsecond), "c08a7d7f80c00400", typeio.AppendUnixMicro64LE, typeio.DecodeUnixMicro64LE)
+	testAppendDecode(t, "UnixNano64BE", tm, "128ff5f202660115", typeio.AppendUnixNano64BE, typeio.DecodeUnixNanoUTC64BE)
+	testAppendDecode(t, "UnixNano64LE", tm, "15016602f2f58f12", typeio.AppendUnixNano64LE, typeio.DecodeUnixNano64LE)
+	testAppendDecode(t, "NTPTimestamp", tm, "d36402921f9add37", typeio.AppendNTPTimestamp, typeio.DecodeNTPTimestamp)
+	testAppendDecode(t, "NTPShort", 1500*time.Millisecond, "00018000", typeio.AppendNTPShort, typeio.DecodeNTPShort)
+	testAppendDecode(t, "WindowsFileTime", tm.Truncate(100), "87eb25d0e336cd01", typeio.AppendWindowsFileTime, typeio.DecodeWindowsFileTime)
+	testAppendDecode(t, "DotNetDateTime", tm.Truncate(100), "87eb9cf2fa04cf48",
+		func(dst []byte, t time.Time) ([]byte, error) {
+			return typeio.AppendDotNetDateTime(dst, t, typeio.DotNetKindUTC)
+		},
+		func(b []byte) (time.Time, []byte, error) {
+			t, _, rest, err := typeio.DecodeDotNetDateTime(b)
+			return t, rest, err
+		},
+	)
+	testAppendDecode(t, "DOSTimeDate", sec, "bbbeb440", typeio.AppendDOSTimeDate,
+		func(b []byte) (time.Time, []byte, error) { return typeio.DecodeDOSTimeDate(b, nil) },
+	)
+	testAppendDecode(t, "DOSDateTime", sec, "b440bbbe", typeio.AppendDOSDateTime,
+		func(b []byte) (time.Time, []byte, error) { return typeio.DecodeDOSDateTime(b, time.UTC) },
+	)
+	testAppendDecode(t, "HFSPlusTime", sec, "cbdf3492", typeio.AppendHFSPlusTime, typeio.DecodeHFSPlusTime)
+	testAppendDecode(t, "HFSTime", sec, "cbdf3492", typeio.AppendHFSTime,
+		func(b []byte) (time.Time, []byte, error) { return typeio.DecodeHFSTime(b, nil) },
+	)
+	testAppendDecode(t, "GPSTimeLE", sec, "990621500100",
+		func(dst []byte, t time.Time) ([]byte, error) { return typeio.AppendGPSTimeLE(dst, t, nil) },
+		func(b []byte) (time.Time, []byte, error) { return typeio.DecodeGPSTimeLE(b, nil) },
+	)
+	testAppendDecode(t, "GPSTimeBE", sec, "069900015021",
+		func(dst []byte, t time.Time) ([]byte, error) { return typeio.AppendGPSTimeBE(dst, t, nil) },
+		func(b []byte) (time.Time, []byte, error) { return typeio.DecodeGPSTimeBE(b, nil) },
+	)
+	testAppendDecode(t, "TAI64", sec, "400000004fb98434",
+		func(dst []byte, t time.Time) ([]byte, error) { return typeio.AppendTAI64(dst, t, nil) },
+		func(b []byte) (time.Time, []byte, error) { return typeio.DecodeTAI64(b, nil) },
+	)
+	testAppendDecode(t, "TAI64N", tm, "400000004fb98434075bcd15",
+		func(dst []byte, t time.Time) ([]byte, error) { return typeio.AppendTAI64N(dst, t, nil) },
+		func(b []byte) (time.Time, []byte, error) { return typeio.DecodeTAI64N(b, nil) },
+	)
+	testAppendDecode(t, "DurationLE", 1500*time.Millisecond, "dc05",
+		func(dst []byte, d time.Duration) ([]byte, error) {
+			return typeio.AppendDurationLE(dst, d, time.Millisecond, 2)
+		},
+		func(b []byte) (time.Duration, []byte, error) { return typeio.DecodeDurationLE(b, time.Millisecond, 2) },
+	)
+	testAppendDecode(t, "DurationBE", 90*time.Second, "0000005a",
+		func(dst []byte, d time.Duration) ([]byte, error) {
+			return typeio.AppendDurationBE(dst, d, time.Second, 4)
+		},
+		func(b []byte) (time.Duration, []byte, error) { return typeio.DecodeDurationBE(b, time.Second, 4) },
+	)
+	testAppendDecode(t, "DurationSecNanosBE", -1500*time.Millisecond, "ffffffffffffffffe2329b00",
+		noErr(typeio.AppendDurationSecNanosBE), typeio.DecodeDurationSecNanosBE)
+	testAppendDecode(t, "DurationSecNanosLE", 1500*time.Millisecond, "01000000000000000065cd1d",
+		noErr(typeio.AppendDurationSecNanosLE), typeio.DecodeDurationSecNanosLE)
+}
+
+func TestAppendDecodeTime_error(t *testing.T) {
+	dst := []byte{1}
+	before := time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, f := range []func([]byte) ([]byte, error){
+		func(b []byte) ([]byte, error) { return typeio.AppendUnixTimeUint32BE(b, before) },
+		func(b []byte) ([]byte, error) { return typeio.AppendNTPTimestamp(b, before) },
+		func(b []byte) ([]byte, error) { return typeio.AppendDOSDateTime(b, before) },
+		func(b []byte) ([]byte, error) { return typeio.AppendGPSTimeBE(b, before, nil) },
+	} {
+		if b, err := f(dst); !errors.Is(err, typeio.ErrTimeOutOfRange) || !bytes.Equal(b, dst) {
+			t.Errorf("#%d: unexpected result: %x, %v", i, b, err)
+		}
+	}
+	if b, err := typeio.AppendDurationLE(dst, time.Second, time.Second, 3); !errors.Is(err, typeio.ErrInvalidSize) || !bytes.Equal(b, dst) {
+		t.Errorf("unexpected result: %x, %v", b, err)
+	}
+
+	invalid := []byte{0x00, 0x00, 0x00, 0x00, 0xff}
+	if _, rest, err := typeio.DecodeDOSDateTime(invalid, nil); !errors.Is(err, typeio.ErrInvalidTime) || !bytes.Equal(rest, invalid) {
+		t.Errorf("unexpected result: %x, %v", rest, err)
+	}
+	invalid = []byte{0x00, 0x00, 0xff, 0xff, 0xff, 0xff}
+	if _, rest, err := typeio.DecodeGPSTimeBE(invalid, nil); !errors.Is(err, typeio.ErrInvalidTime) || !bytes.Equal(rest, invalid) {
+		t.Errorf("unexpected result: %x, %v", rest, err)
+	}
+}
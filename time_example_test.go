@@ -136,3 +136,272 @@ func ExampleWriteUnixTime32LE() {
 	// Output:
 	// 1284b94f1284b94f3b14504b
 }
+
+func ExampleReadUnixTimeUTC64BE() {
+	b, _ := hex.DecodeString("000000004fb98412fffffffff0000000")
+	r := bytes.NewReader(b)
+
+	for {
+		t, err := typeio.ReadUnixTimeUTC64BE(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			panic(err)
+		}
+		fmt.Println(t)
+	}
+
+	// Output:
+	// 2012-05-20 23:53:54 +0000 UTC
+	// 1961-06-30 02:35:44 +0000 UTC
+}
+
+func ExampleReadUnixMilliUTC64BE() {
+	b, _ := hex.DecodeString("000001376cabe6cb")
+	r := bytes.NewReader(b)
+
+	t, err := typeio.ReadUnixMilliUTC64BE(r)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(t)
+
+	// Output:
+	// 2012-05-20 23:53:54.123 +0000 UTC
+}
+
+func ExampleWriteUnixNano64LE() {
+	w := new(bytes.Buffer)
+
+	data := []time.Time{
+		time.Date(2012, 5, 20, 23, 53, 54, 123456789, time.UTC),
+		time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for _, t := range data {
+		if err := typeio.WriteUnixNano64LE(w, t); err != nil {
+			fmt.Println(err)
+			continue
+		}
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	// Output:
+	// time out of range: 2300-01-01 00:00:00 +0000 UTC
+	// 15016602f2f58f12
+}
+
+func ExampleWriteUnixTimeUint32BE() {
+	w := new(bytes.Buffer)
+
+	data := []time.Time{
+		time.Date(2012, 5, 20, 23, 53, 54, 0, time.UTC),
+		time.Date(1969, 7, 20, 20, 17, 40, 0, time.UTC),
+	}
+	for _, t := range data {
+		if err := typeio.WriteUnixTimeUint32BE(w, t); err != nil {
+			fmt.Println(err)
+		}
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	// Output:
+	// time out of range: 1969-07-20 20:17:40 +0000 UTC
+	// 4fb98412
+}
+
+func ExampleWriteUnixTimeInt32BE() {
+	w := new(bytes.Buffer)
+
+	data := []time.Time{
+		time.Date(1969, 7, 20, 20, 17, 40, 0, time.UTC),
+		time.Date(2038, 1, 19, 3, 14, 8, 0, time.UTC),
+	}
+	for _, t := range data {
+		if err := typeio.WriteUnixTimeInt32BE(w, t); err != nil {
+			fmt.Println(err)
+		}
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	// Output:
+	// time out of range: 2038-01-19 03:14:08 +0000 UTC
+	// ff2795e4
+}
+
+func ExampleReadNTPTimestamp() {
+	b, _ := hex.DecodeString("d36402921f9add370000000080000000")
+	r := bytes.NewReader(b)
+
+	for {
+		t, err := typeio.ReadNTPTimestamp(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			panic(err)
+		}
+		fmt.Println(t)
+	}
+
+	// Output:
+	// 2012-05-20 23:53:54.123456789 +0000 UTC
+	// 2036-02-07 06:28:16.5 +0000 UTC
+}
+
+func ExampleWriteNTPTimestamp() {
+	w := new(bytes.Buffer)
+
+	t := time.Date(2012, 5, 20, 23, 53, 54, 123456789, time.UTC)
+	if err := typeio.WriteNTPTimestamp(w, t); err != nil {
+		panic(err)
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	// Output:
+	// d36402921f9add37
+}
+
+func ExampleReadNTPShort() {
+	b, _ := hex.DecodeString("00018000")
+	r := bytes.NewReader(b)
+
+	d, err := typeio.ReadNTPShort(r)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(d)
+
+	// Output:
+	// 1.5s
+}
+
+func ExampleReadWindowsFileTime() {
+	b, _ := hex.DecodeString("87eb25d0e336cd01")
+	r := bytes.NewReader(b)
+
+	t, err := typeio.ReadWindowsFileTime(r)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(t)
+
+	// Output:
+	// 2012-05-20 23:53:54.1234567 +0000 UTC
+}
+
+func ExampleReadDotNetDateTime() {
+	b, _ := hex.DecodeString("87eb9cf2fa04cf4887eb9cf2fa04cf08")
+	r := bytes.NewReader(b)
+
+	for {
+		t, kind, err := typeio.ReadDotNetDateTime(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			panic(err)
+		}
+		fmt.Println(t, kind == typeio.DotNetKindUTC)
+	}
+
+	// Output:
+	// 2012-05-20 23:53:54.1234567 +0000 UTC true
+	// 2012-05-20 23:53:54.1234567 +0000 UTC false
+}
+
+func ExampleWriteDotNetDateTime() {
+	w := new(bytes.Buffer)
+
+	t := time.Date(2012, 5, 20, 23, 53, 54, 123456789, time.UTC)
+	if err := typeio.WriteDotNetDateTime(w, t, typeio.DotNetKindUTC); err != nil {
+		panic(err)
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	// Output:
+	// 87eb9cf2fa04cf48
+}
+
+func ExampleReadDOSTimeDate() {
+	b, _ := hex.DecodeString("bbbeb440")
+	r := bytes.NewReader(b)
+
+	locJST, _ := time.LoadLocation("Asia/Tokyo")
+	t, err := typeio.ReadDOSTimeDate(r, locJST)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(t)
+
+	// Output:
+	// 2012-05-20 23:53:54 +0900 JST
+}
+
+func ExampleWriteDOSDateTime() {
+	w := new(bytes.Buffer)
+
+	data := []time.Time{
+		time.Date(2012, 5, 20, 23, 53, 55, 0, time.UTC),
+		time.Date(1979, 12, 31, 23, 59, 59, 0, time.UTC),
+	}
+	for _, t := range data {
+		if err := typeio.WriteDOSDateTime(w, t); err != nil {
+			fmt.Println(err)
+		}
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	// Output:
+	// time out of range: 1979-12-31 23:59:59 +0000 UTC
+	// b440bbbe
+}
+
+func ExampleReadGPSTimeBE() {
+	b, _ := hex.DecodeString("069900015021")
+	r := bytes.NewReader(b)
+
+	t, err := typeio.ReadGPSTimeBE(r, nil)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(t)
+
+	// Output:
+	// 2012-05-20 23:53:54 +0000 UTC
+}
+
+func ExampleReadTAI64N() {
+	// A label written by the tai64n program of daemontools.
+	b, _ := hex.DecodeString("400000004fb9841c075bcd15")
+	r := bytes.NewReader(b)
+
+	t, err := typeio.ReadTAI64N(r, typeio.LeapSecondTable{{Offset: 10}})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(t)
+
+	// Output:
+	// 2012-05-20 23:53:54.123456789 +0000 UTC
+}
+
+func ExampleWriteDurationBE() {
+	w := new(bytes.Buffer)
+
+	// timeout in milliseconds as uint32
+	if err := typeio.WriteDurationBE(w, 1500*time.Millisecond, time.Millisecond, 4); err != nil {
+		panic(err)
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	d, err := typeio.ReadDurationBE(w, time.Millisecond, 4)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(d)
+
+	// Output:
+	// 000005dc
+	// 1.5s
+}
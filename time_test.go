@@ -9,6 +9,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"io"
+	"math"
 	"testing"
 	"time"
 
@@ -227,3 +228,1069 @@ func TestWriteUnixTime32LE(t *testing.T) {
 		}
 	}
 }
+
+func TestReadUnixTime64(t *testing.T) {
+	locJST, _ := time.LoadLocation("Asia/Tokyo")
+	time.Local = locJST
+	type readFunc func(io.Reader) (time.Time, error)
+	tcs := []struct {
+		utc, local readFunc
+		b          string
+		t          time.Time
+		e          error
+	}{
+		{
+			typeio.ReadUnixTimeUTC64BE, typeio.ReadUnixTime64BE,
+			"000000004fb98412", time.Date(2012, 5, 20, 23, 53, 54, 0, time.UTC), nil,
+		},
+		{
+			typeio.ReadUnixTimeUTC64BE, typeio.ReadUnixTime64BE,
+			"0000000100000000", time.Date(2106, 2, 7, 6, 28, 16, 0, time.UTC), nil,
+		},
+		{
+			typeio.ReadUnixTimeUTC64BE, typeio.ReadUnixTime64BE,
+			"ffffffffffffffff", time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC), nil,
+		},
+		{
+			typeio.ReadUnixTimeUTC64LE, typeio.ReadUnixTime64LE,
+			"1284b94f00000000", time.Date(2012, 5, 20, 23, 53, 54, 0, time.UTC), nil,
+		},
+		{
+			typeio.ReadUnixMilliUTC64BE, typeio.ReadUnixMilli64BE,
+			"000001376cabe6cb", time.Date(2012, 5, 20, 23, 53, 54, 123e6, time.UTC), nil,
+		},
+		{
+			typeio.ReadUnixMilliUTC64BE, typeio.ReadUnixMilli64BE,
+			"ffffffffffffffff", time.Date(1969, 12, 31, 23, 59, 59, 999e6, time.UTC), nil,
+		},
+		{
+			typeio.ReadUnixMilliUTC64LE, typeio.ReadUnixMilli64LE,
+			"cbe6ab6c37010000", time.Date(2012, 5, 20, 23, 53, 54, 123e6, time.UTC), nil,
+		},
+		{
+			typeio.ReadUnixMicroUTC64BE, typeio.ReadUnixMicro64BE,
+			"0004c0807f7d8ac0", time.Date(2012, 5, 20, 23, 53, 54, 123456e3, time.UTC), nil,
+		},
+		{
+			typeio.ReadUnixMicroUTC64LE, typeio.ReadUnixMicro64LE,
+			"c08a7d7f80c00400", time.Date(2012, 5, 20, 23, 53, 54, 123456e3, time.UTC), nil,
+		},
+		{
+			typeio.ReadUnixNanoUTC64BE, typeio.ReadUnixNano64BE,
+			"128ff5f202660115", time.Date(2012, 5, 20, 23, 53, 54, 123456789, time.UTC), nil,
+		},
+		{
+			typeio.ReadUnixNanoUTC64LE, typeio.ReadUnixNano64LE,
+			"15016602f2f58f12", time.Date(2012, 5, 20, 23, 53, 54, 123456789, time.UTC), nil,
+		},
+		{
+			typeio.ReadUnixNanoUTC64LE, typeio.ReadUnixNano64LE,
+			"0000000000000080", time.Date(1677, 9, 21, 0, 12, 43, 145224192, time.UTC), nil,
+		},
+		{typeio.ReadUnixTimeUTC64BE, typeio.ReadUnixTime64BE, "", time.Time{}, io.EOF},
+		{typeio.ReadUnixMilliUTC64LE, typeio.ReadUnixMilli64LE, "0001020304", time.Time{}, io.ErrUnexpectedEOF},
+	}
+	for i, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("#%d: invalid test data: %s", i, err)
+			continue
+		}
+		for _, f := range []struct {
+			read readFunc
+			loc  *time.Location
+		}{{tc.utc, time.UTC}, {tc.local, locJST}} {
+			got, err := f.read(bytes.NewReader(b))
+			switch {
+			case tc.e != nil && err == nil:
+				t.Errorf("#%d: error expected: got %v", i, got)
+			case tc.e != nil && !errors.Is(err, tc.e):
+				t.Errorf("#%d: unexpected type of error: got %q, want %q", i, err, tc.e)
+			case tc.e == nil && err != nil:
+				t.Errorf("#%d: unexpected error: %s", i, err)
+			case tc.e == nil && !got.Equal(tc.t):
+				t.Errorf("#%d: unexpected read: got %v, want %v", i, got, tc.t)
+			case tc.e == nil && !loceq(got.Location(), f.loc):
+				t.Errorf("#%d: unexpected loc: got %s, want %s", i, got.Location(), f.loc)
+			}
+		}
+	}
+}
+
+func TestWriteUnixTime64(t *testing.T) {
+	locJST, _ := time.LoadLocation("Asia/Tokyo")
+	tcs := []struct {
+		write func(io.Writer, time.Time) error
+		t     time.Time
+		b     string
+		e     error
+	}{
+		{typeio.WriteUnixTime64BE, time.Date(2012, 5, 21, 8, 53, 54, 999, locJST), "000000004fb98412", nil},
+		{typeio.WriteUnixTime64BE, time.Date(2106, 2, 7, 6, 28, 16, 0, time.UTC), "0000000100000000", nil},
+		{typeio.WriteUnixTime64BE, time.Date(1969, 12, 31, 23, 59, 59, 500, time.UTC), "ffffffffffffffff", nil},
+		{typeio.WriteUnixTime64LE, time.Date(2012, 5, 20, 23, 53, 54, 0, time.UTC), "1284b94f00000000", nil},
+		{typeio.WriteUnixMilli64BE, time.Date(2012, 5, 20, 23, 53, 54, 123456789, time.UTC), "000001376cabe6cb", nil},
+		{typeio.WriteUnixMilli64BE, time.Date(1969, 12, 31, 23, 59, 59, 999999999, time.UTC), "ffffffffffffffff", nil},
+		{typeio.WriteUnixMilli64LE, time.Date(2012, 5, 20, 23, 53, 54, 123e6, time.UTC), "cbe6ab6c37010000", nil},
+		{typeio.WriteUnixMicro64BE, time.Date(2012, 5, 20, 23, 53, 54, 123456789, time.UTC), "0004c0807f7d8ac0", nil},
+		{typeio.WriteUnixMicro64LE, time.Date(2012, 5, 20, 23, 53, 54, 123456e3, time.UTC), "c08a7d7f80c00400", nil},
+		{typeio.WriteUnixNano64BE, time.Date(2012, 5, 20, 23, 53, 54, 123456789, time.UTC), "128ff5f202660115", nil},
+		{typeio.WriteUnixNano64LE, time.Date(2012, 5, 20, 23, 53, 54, 123456789, time.UTC), "15016602f2f58f12", nil},
+		{typeio.WriteUnixNano64LE, time.Date(1677, 9, 21, 0, 12, 43, 145224192, time.UTC), "0000000000000080", nil},
+		{typeio.WriteUnixNano64BE, time.Date(1677, 9, 21, 0, 12, 43, 145224191, time.UTC), "", typeio.ErrTimeOutOfRange},
+		{typeio.WriteUnixNano64BE, time.Date(2262, 4, 11, 23, 47, 16, 854775807, time.UTC), "7fffffffffffffff", nil},
+		{typeio.WriteUnixNano64BE, time.Date(2262, 4, 11, 23, 47, 16, 854775808, time.UTC), "", typeio.ErrTimeOutOfRange},
+		{typeio.WriteUnixMilli64LE, time.Date(300000000, 1, 1, 0, 0, 0, 0, time.UTC), "", typeio.ErrTimeOutOfRange},
+	}
+	for i, tc := range tcs {
+		w := new(bytes.Buffer)
+		err := tc.write(w, tc.t)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("#%d: %v: error expected.", i, tc.t)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("#%d: %v: unexpected type of error: got %q, want %q", i, tc.t, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("#%d: %v: unexpected error: %s", i, tc.t, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("#%d: %v: unexpected write: got %s, want %s", i, tc.t, got, tc.b)
+		}
+	}
+}
+
+func TestReadUnixTimeInt32(t *testing.T) {
+	locJST, _ := time.LoadLocation("Asia/Tokyo")
+	time.Local = locJST
+	type readFunc func(io.Reader) (time.Time, error)
+	tcs := []struct {
+		utc, local readFunc
+		b          string
+		t          time.Time
+		e          error
+	}{
+		{
+			typeio.ReadUnixTimeUTCInt32BE, typeio.ReadUnixTimeInt32BE,
+			"4fb98412", time.Date(2012, 5, 20, 23, 53, 54, 0, time.UTC), nil,
+		},
+		{
+			typeio.ReadUnixTimeUTCInt32BE, typeio.ReadUnixTimeInt32BE,
+			"7fffffff", time.Date(2038, 1, 19, 3, 14, 7, 0, time.UTC), nil,
+		},
+		{
+			typeio.ReadUnixTimeUTCInt32BE, typeio.ReadUnixTimeInt32BE,
+			"80000000", time.Date(1901, 12, 13, 20, 45, 52, 0, time.UTC), nil,
+		},
+		{
+			typeio.ReadUnixTimeUTCInt32LE, typeio.ReadUnixTimeInt32LE,
+			"ffffffff", time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC), nil,
+		},
+		{
+			typeio.ReadUnixTimeUTCInt32LE, typeio.ReadUnixTimeInt32LE,
+			"1284b94f", time.Date(2012, 5, 20, 23, 53, 54, 0, time.UTC), nil,
+		},
+		{typeio.ReadUnixTimeUTCInt32BE, typeio.ReadUnixTimeInt32BE, "", time.Time{}, io.EOF},
+		{typeio.ReadUnixTimeUTCInt32LE, typeio.ReadUnixTimeInt32LE, "c0a8ff", time.Time{}, io.ErrUnexpectedEOF},
+	}
+	for i, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("#%d: invalid test data: %s", i, err)
+			continue
+		}
+		for _, f := range []struct {
+			read readFunc
+			loc  *time.Location
+		}{{tc.utc, time.UTC}, {tc.local, locJST}} {
+			got, err := f.read(bytes.NewReader(b))
+			switch {
+			case tc.e != nil && err == nil:
+				t.Errorf("#%d: error expected: got %v", i, got)
+			case tc.e != nil && !errors.Is(err, tc.e):
+				t.Errorf("#%d: unexpected type of error: got %q, want %q", i, err, tc.e)
+			case tc.e == nil && err != nil:
+				t.Errorf("#%d: unexpected error: %s", i, err)
+			case tc.e == nil && !got.Equal(tc.t):
+				t.Errorf("#%d: unexpected read: got %v, want %v", i, got, tc.t)
+			case tc.e == nil && !loceq(got.Location(), f.loc):
+				t.Errorf("#%d: unexpected loc: got %s, want %s", i, got.Location(), f.loc)
+			}
+		}
+	}
+}
+
+func TestWriteUnixTimeChecked32(t *testing.T) {
+	locJST, _ := time.LoadLocation("Asia/Tokyo")
+	tcs := []struct {
+		write func(io.Writer, time.Time) error
+		t     time.Time
+		b     string
+		e     error
+	}{
+		{typeio.WriteUnixTimeUint32BE, time.Date(2012, 5, 21, 8, 53, 54, 0, locJST), "4fb98412", nil},
+		{typeio.WriteUnixTimeUint32BE, time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), "00000000", nil},
+		{typeio.WriteUnixTimeUint32BE, time.Date(2106, 2, 7, 6, 28, 15, 999999999, time.UTC), "ffffffff", nil},
+		{typeio.WriteUnixTimeUint32BE, time.Date(2106, 2, 7, 6, 28, 16, 0, time.UTC), "", typeio.ErrTimeOutOfRange},
+		{typeio.WriteUnixTimeUint32LE, time.Date(1969, 12, 31, 23, 59, 59, 999999999, time.UTC), "", typeio.ErrTimeOutOfRange},
+		{typeio.WriteUnixTimeUint32LE, time.Date(2012, 5, 20, 23, 53, 54, 0, time.UTC), "1284b94f", nil},
+		{typeio.WriteUnixTimeInt32BE, time.Date(2012, 5, 20, 23, 53, 54, 0, time.UTC), "4fb98412", nil},
+		{typeio.WriteUnixTimeInt32BE, time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC), "ffffffff", nil},
+		{typeio.WriteUnixTimeInt32BE, time.Date(1901, 12, 13, 20, 45, 52, 0, time.UTC), "80000000", nil},
+		{typeio.WriteUnixTimeInt32BE, time.Date(1901, 12, 13, 20, 45, 51, 0, time.UTC), "", typeio.ErrTimeOutOfRange},
+		{typeio.WriteUnixTimeInt32LE, time.Date(2038, 1, 19, 3, 14, 7, 0, time.UTC), "ffffff7f", nil},
+		{typeio.WriteUnixTimeInt32LE, time.Date(2038, 1, 19, 3, 14, 8, 0, time.UTC), "", typeio.ErrTimeOutOfRange},
+	}
+	for i, tc := range tcs {
+		w := new(bytes.Buffer)
+		err := tc.write(w, tc.t)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("#%d: %v: error expected.", i, tc.t)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("#%d: %v: unexpected type of error: got %q, want %q", i, tc.t, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("#%d: %v: unexpected error: %s", i, tc.t, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("#%d: %v: unexpected write: got %s, want %s", i, tc.t, got, tc.b)
+		}
+	}
+}
+
+func TestReadNTPTimestamp(t *testing.T) {
+	tcs := []struct {
+		b string
+		t time.Time
+		e error
+	}{
+		{"d364029200000000", time.Date(2012, 5, 20, 23, 53, 54, 0, time.UTC), nil},
+		{"d36402921f9add37", time.Date(2012, 5, 20, 23, 53, 54, 123456789, time.UTC), nil},
+		{"d364029280000000", time.Date(2012, 5, 20, 23, 53, 54, 5e8, time.UTC), nil},
+		{"d3640292ffffffff", time.Date(2012, 5, 20, 23, 53, 55, 0, time.UTC), nil},
+		{"83aa7e8000000000", time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), nil},
+		{"8000000000000000", time.Date(1968, 1, 20, 3, 14, 8, 0, time.UTC), nil},
+		{"ffffffff00000000", time.Date(2036, 2, 7, 6, 28, 15, 0, time.UTC), nil},
+		{"0000000000000000", time.Date(2036, 2, 7, 6, 28, 16, 0, time.UTC), nil},
+		{"7fffffff00000000", time.Date(2104, 2, 26, 9, 42, 23, 0, time.UTC), nil},
+		{"", time.Time{}, io.EOF},
+		{"d3640292", time.Time{}, io.ErrUnexpectedEOF},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		got, err := typeio.ReadNTPTimestamp(bytes.NewReader(b))
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%q: error expected: got %v", tc.b, got)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%q: unexpected type of error: got %q, want %q", tc.b, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%q: unexpected error: %s", tc.b, err)
+		case tc.e == nil && !got.Equal(tc.t):
+			t.Errorf("%q: unexpected read: got %v, want %v", tc.b, got, tc.t)
+		case tc.e == nil && !loceq(got.Location(), time.UTC):
+			t.Errorf("%q: unexpected loc: got %s, want %s", tc.b, got.Location(), time.UTC)
+		}
+	}
+}
+
+func TestWriteNTPTimestamp(t *testing.T) {
+	locJST, _ := time.LoadLocation("Asia/Tokyo")
+	tcs := []struct {
+		t time.Time
+		b string
+		e error
+	}{
+		{time.Date(2012, 5, 21, 8, 53, 54, 0, locJST), "d364029200000000", nil},
+		{time.Date(2012, 5, 20, 23, 53, 54, 123456789, time.UTC), "d36402921f9add37", nil},
+		{time.Date(2012, 5, 20, 23, 53, 54, 5e8, time.UTC), "d364029280000000", nil},
+		{time.Date(2012, 5, 20, 23, 53, 54, 999999999, time.UTC), "d3640292fffffffc", nil},
+		{time.Date(1968, 1, 20, 3, 14, 8, 0, time.UTC), "8000000000000000", nil},
+		{time.Date(1968, 1, 20, 3, 14, 7, 999999999, time.UTC), "", typeio.ErrTimeOutOfRange},
+		{time.Date(2036, 2, 7, 6, 28, 16, 0, time.UTC), "0000000000000000", nil},
+		{time.Date(2104, 2, 26, 9, 42, 23, 999999999, time.UTC), "7ffffffffffffffc", nil},
+		{time.Date(2104, 2, 26, 9, 42, 24, 0, time.UTC), "", typeio.ErrTimeOutOfRange},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		err := typeio.WriteNTPTimestamp(w, tc.t)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%v: error expected.", tc.t)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%v: unexpected type of error: got %q, want %q", tc.t, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%v: unexpected error: %s", tc.t, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%v: unexpected write: got %s, want %s", tc.t, got, tc.b)
+		}
+		if tc.e != nil {
+			continue
+		}
+		got, err := typeio.ReadNTPTimestamp(w)
+		if err != nil {
+			t.Errorf("%v: unexpected read error: %s", tc.t, err)
+		} else if !got.Equal(tc.t) {
+			t.Errorf("%v: unexpected round trip: got %v", tc.t, got)
+		}
+	}
+}
+
+func TestReadNTPShort(t *testing.T) {
+	tcs := []struct {
+		b string
+		d time.Duration
+		e error
+	}{
+		{"00000000", 0, nil},
+		{"00018000", 1500 * time.Millisecond, nil},
+		{"00000001", 15259 * time.Nanosecond, nil},
+		{"ffffffff", 65535*time.Second + 999984741, nil},
+		{"", 0, io.EOF},
+		{"0001", 0, io.ErrUnexpectedEOF},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		got, err := typeio.ReadNTPShort(bytes.NewReader(b))
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%q: error expected: got %v", tc.b, got)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%q: unexpected type of error: got %q, want %q", tc.b, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%q: unexpected error: %s", tc.b, err)
+		case tc.e == nil && got != tc.d:
+			t.Errorf("%q: unexpected read: got %v, want %v", tc.b, got, tc.d)
+		}
+	}
+}
+
+func TestWriteNTPShort(t *testing.T) {
+	tcs := []struct {
+		d time.Duration
+		b string
+		e error
+	}{
+		{0, "00000000", nil},
+		{1500 * time.Millisecond, "00018000", nil},
+		{7629 * time.Nanosecond, "00000000", nil},
+		{7630 * time.Nanosecond, "00000001", nil},
+		{65535*time.Second + 999984741, "ffffffff", nil},
+		{65535*time.Second + 999992370, "ffffffff", nil},
+		{65535*time.Second + 999992371, "", typeio.ErrValueOutOfRange},
+		{65536 * time.Second, "", typeio.ErrValueOutOfRange},
+		{-time.Nanosecond, "", typeio.ErrValueOutOfRange},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		err := typeio.WriteNTPShort(w, tc.d)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%v: error expected.", tc.d)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%v: unexpected type of error: got %q, want %q", tc.d, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%v: unexpected error: %s", tc.d, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%v: unexpected write: got %s, want %s", tc.d, got, tc.b)
+		}
+	}
+}
+
+func TestReadWindowsFileTime(t *testing.T) {
+	tcs := []struct {
+		b string
+		t time.Time
+		e error
+	}{
+		{"87eb25d0e336cd01", time.Date(2012, 5, 20, 23, 53, 54, 123456700, time.UTC), nil},
+		{"00803ed5deb19d01", time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), nil},
+		{"0000000000000000", time.Date(1601, 1, 1, 0, 0, 0, 0, time.UTC), nil},
+		{"ffffffffffffffff", time.Date(60056, 5, 28, 5, 36, 10, 955161500, time.UTC), nil},
+		{"", time.Time{}, io.EOF},
+		{"87eb25d0", time.Time{}, io.ErrUnexpectedEOF},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		got, err := typeio.ReadWindowsFileTime(bytes.NewReader(b))
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%q: error expected: got %v", tc.b, got)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%q: unexpected type of error: got %q, want %q", tc.b, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%q: unexpected error: %s", tc.b, err)
+		case tc.e == nil && !got.Equal(tc.t):
+			t.Errorf("%q: unexpected read: got %v, want %v", tc.b, got, tc.t)
+		case tc.e == nil && !loceq(got.Location(), time.UTC):
+			t.Errorf("%q: unexpected loc: got %s, want %s", tc.b, got.Location(), time.UTC)
+		}
+	}
+}
+
+func TestWriteWindowsFileTime(t *testing.T) {
+	locJST, _ := time.LoadLocation("Asia/Tokyo")
+	tcs := []struct {
+		t time.Time
+		b string
+		e error
+	}{
+		{time.Date(2012, 5, 20, 23, 53, 54, 123456789, time.UTC), "87eb25d0e336cd01", nil},
+		{time.Date(2012, 5, 21, 8, 53, 54, 123456700, locJST), "87eb25d0e336cd01", nil},
+		{time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), "00803ed5deb19d01", nil},
+		{time.Date(1601, 1, 1, 0, 0, 0, 0, time.UTC), "0000000000000000", nil},
+		{time.Date(1600, 12, 31, 23, 59, 59, 999999999, time.UTC), "", typeio.ErrTimeOutOfRange},
+		{time.Date(60056, 5, 28, 5, 36, 10, 955161599, time.UTC), "ffffffffffffffff", nil},
+		{time.Date(60056, 5, 28, 5, 36, 10, 955161600, time.UTC), "", typeio.ErrTimeOutOfRange},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		err := typeio.WriteWindowsFileTime(w, tc.t)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%v: error expected.", tc.t)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%v: unexpected type of error: got %q, want %q", tc.t, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%v: unexpected error: %s", tc.t, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%v: unexpected write: got %s, want %s", tc.t, got, tc.b)
+		}
+	}
+}
+
+func TestReadDotNetDateTime(t *testing.T) {
+	locJST, _ := time.LoadLocation("Asia/Tokyo")
+	time.Local = locJST
+	tcs := []struct {
+		b   string
+		t   time.Time
+		k   typeio.DotNetKind
+		loc *time.Location
+		e   error
+	}{
+		{
+			"87eb9cf2fa04cf08", time.Date(2012, 5, 20, 23, 53, 54, 123456700, time.UTC),
+			typeio.DotNetKindUnspecified, time.UTC, nil,
+		},
+		{
+			"87eb9cf2fa04cf48", time.Date(2012, 5, 20, 23, 53, 54, 123456700, time.UTC),
+			typeio.DotNetKindUTC, time.UTC, nil,
+		},
+		{
+			"87eb9cf2fa04cf88", time.Date(2012, 5, 20, 23, 53, 54, 123456700, locJST),
+			typeio.DotNetKindLocal, locJST, nil,
+		},
+		{
+			"87eb9cf2fa04cfc8", time.Date(2012, 5, 20, 23, 53, 54, 123456700, locJST),
+			typeio.DotNetKindLocal, locJST, nil,
+		},
+		{
+			"0000000000000040", time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+			typeio.DotNetKindUTC, time.UTC, nil,
+		},
+		{
+			"ff3f37f47528ca6b", time.Date(9999, 12, 31, 23, 59, 59, 999999900, time.UTC),
+			typeio.DotNetKindUTC, time.UTC, nil,
+		},
+		{"004037f47528ca6b", time.Time{}, 0, nil, typeio.ErrInvalidTime},
+		{"", time.Time{}, 0, nil, io.EOF},
+		{"87eb9cf2fa04cf", time.Time{}, 0, nil, io.ErrUnexpectedEOF},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		got, kind, err := typeio.ReadDotNetDateTime(bytes.NewReader(b))
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%q: error expected: got %v", tc.b, got)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%q: unexpected type of error: got %q, want %q", tc.b, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%q: unexpected error: %s", tc.b, err)
+		case tc.e == nil && !got.Equal(tc.t):
+			t.Errorf("%q: unexpected read: got %v, want %v", tc.b, got, tc.t)
+		case tc.e == nil && kind != tc.k:
+			t.Errorf("%q: unexpected kind: got %d, want %d", tc.b, kind, tc.k)
+		case tc.e == nil && !loceq(got.Location(), tc.loc):
+			t.Errorf("%q: unexpected loc: got %s, want %s", tc.b, got.Location(), tc.loc)
+		}
+	}
+}
+
+func TestWriteDotNetDateTime(t *testing.T) {
+	locJST, _ := time.LoadLocation("Asia/Tokyo")
+	time.Local = locJST
+	tcs := []struct {
+		t time.Time
+		k typeio.DotNetKind
+		b string
+		e error
+	}{
+		{time.Date(2012, 5, 20, 23, 53, 54, 123456789, time.UTC), typeio.DotNetKindUnspecified, "87eb9cf2fa04cf08", nil},
+		{time.Date(2012, 5, 20, 23, 53, 54, 123456789, locJST), typeio.DotNetKindUnspecified, "87eb9cf2fa04cf08", nil},
+		{time.Date(2012, 5, 21, 8, 53, 54, 123456789, locJST), typeio.DotNetKindUTC, "87eb9cf2fa04cf48", nil},
+		{time.Date(2012, 5, 20, 14, 53, 54, 123456789, time.UTC), typeio.DotNetKindLocal, "87eb9cf2fa04cf88", nil},
+		{time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC), typeio.DotNetKindUTC, "0000000000000040", nil},
+		{time.Date(9999, 12, 31, 23, 59, 59, 999999999, time.UTC), typeio.DotNetKindUTC, "ff3f37f47528ca6b", nil},
+		{time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC), typeio.DotNetKindUTC, "", typeio.ErrTimeOutOfRange},
+		{time.Date(0, 12, 31, 23, 59, 59, 0, time.UTC), typeio.DotNetKindUTC, "", typeio.ErrTimeOutOfRange},
+		{time.Date(2012, 5, 20, 23, 53, 54, 0, time.UTC), 3, "", typeio.ErrValueOutOfRange},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		err := typeio.WriteDotNetDateTime(w, tc.t, tc.k)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%v, %d: error expected.", tc.t, tc.k)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%v, %d: unexpected type of error: got %q, want %q", tc.t, tc.k, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%v, %d: unexpected error: %s", tc.t, tc.k, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%v, %d: unexpected write: got %s, want %s", tc.t, tc.k, got, tc.b)
+		}
+	}
+}
+
+func TestReadDOSDateTime(t *testing.T) {
+	locJST, _ := time.LoadLocation("Asia/Tokyo")
+	tcs := []struct {
+		b   string
+		loc *time.Location
+		t   time.Time
+		e   error
+	}{
+		{"b440bbbe", locJST, time.Date(2012, 5, 20, 23, 53, 54, 0, locJST), nil},
+		{"b440bbbe", nil, time.Date(2012, 5, 20, 23, 53, 54, 0, time.UTC), nil},
+		{"21000000", time.UTC, time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC), nil},
+		{"9fff7dbf", time.UTC, time.Date(2107, 12, 31, 23, 59, 58, 0, time.UTC), nil},
+		{"5d400000", time.UTC, time.Date(2012, 2, 29, 0, 0, 0, 0, time.UTC), nil},
+		{"5d420000", time.UTC, time.Time{}, typeio.ErrInvalidTime},
+		{"a1410000", time.UTC, time.Time{}, typeio.ErrInvalidTime},
+		{"a0400000", time.UTC, time.Time{}, typeio.ErrInvalidTime},
+		{"b44000c0", time.UTC, time.Time{}, typeio.ErrInvalidTime},
+		{"b4408007", time.UTC, time.Time{}, typeio.ErrInvalidTime},
+		{"b4401e00", time.UTC, time.Time{}, typeio.ErrInvalidTime},
+		{"00000000", time.UTC, time.Time{}, typeio.ErrInvalidTime},
+		{"", time.UTC, time.Time{}, io.EOF},
+		{"b440bb", time.UTC, time.Time{}, io.ErrUnexpectedEOF},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		swapped := b
+		if len(b) == 4 {
+			swapped = append(append([]byte{}, b[2:]...), b[:2]...)
+		}
+		for i, rd := range []func() (time.Time, error){
+			func() (time.Time, error) { return typeio.ReadDOSDateTime(bytes.NewReader(b), tc.loc) },
+			func() (time.Time, error) { return typeio.ReadDOSTimeDate(bytes.NewReader(swapped), tc.loc) },
+		} {
+			got, err := rd()
+			switch {
+			case tc.e != nil && err == nil:
+				t.Errorf("%q(%d): error expected: got %v", tc.b, i, got)
+			case tc.e != nil && !errors.Is(err, tc.e):
+				t.Errorf("%q(%d): unexpected type of error: got %q, want %q", tc.b, i, err, tc.e)
+			case tc.e == nil && err != nil:
+				t.Errorf("%q(%d): unexpected error: %s", tc.b, i, err)
+			case tc.e == nil && !got.Equal(tc.t):
+				t.Errorf("%q(%d): unexpected read: got %v, want %v", tc.b, i, got, tc.t)
+			case tc.e == nil && !loceq(got.Location(), tc.t.Location()):
+				t.Errorf("%q(%d): unexpected loc: got %s, want %s", tc.b, i, got.Location(), tc.t.Location())
+			}
+		}
+	}
+}
+
+func TestWriteDOSDateTime(t *testing.T) {
+	locJST, _ := time.LoadLocation("Asia/Tokyo")
+	tcs := []struct {
+		t time.Time
+		b string
+		e error
+	}{
+		{time.Date(2012, 5, 20, 23, 53, 54, 0, time.UTC), "b440bbbe", nil},
+		{time.Date(2012, 5, 20, 23, 53, 55, 999999999, time.UTC), "b440bbbe", nil},
+		{time.Date(2012, 5, 20, 23, 53, 54, 0, locJST), "b440bbbe", nil},
+		{time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC), "21000000", nil},
+		{time.Date(2107, 12, 31, 23, 59, 59, 0, time.UTC), "9fff7dbf", nil},
+		{time.Date(1979, 12, 31, 23, 59, 59, 0, time.UTC), "", typeio.ErrTimeOutOfRange},
+		{time.Date(2108, 1, 1, 0, 0, 0, 0, time.UTC), "", typeio.ErrTimeOutOfRange},
+	}
+	for _, tc := range tcs {
+		for i, wr := range []func(io.Writer, time.Time) error{
+			typeio.WriteDOSDateTime,
+			typeio.WriteDOSTimeDate,
+		} {
+			w := new(bytes.Buffer)
+			err := wr(w, tc.t)
+			switch {
+			case tc.e != nil && err == nil:
+				t.Errorf("%v(%d): error expected.", tc.t, i)
+			case tc.e != nil && !errors.Is(err, tc.e):
+				t.Errorf("%v(%d): unexpected type of error: got %q, want %q", tc.t, i, err, tc.e)
+			case tc.e == nil && err != nil:
+				t.Errorf("%v(%d): unexpected error: %s", tc.t, i, err)
+			}
+			want := tc.b
+			if i == 1 && want != "" {
+				want = want[4:] + want[:4]
+			}
+			if got := hex.EncodeToString(w.Bytes()); got != want {
+				t.Errorf("%v(%d): unexpected write: got %s, want %s", tc.t, i, got, want)
+			}
+		}
+	}
+}
+
+func TestReadHFSPlusTime(t *testing.T) {
+	tcs := []struct {
+		b string
+		t time.Time
+		e error
+	}{
+		{"cbdf3492", time.Date(2012, 5, 20, 23, 53, 54, 0, time.UTC), nil},
+		{"00000000", time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC), nil},
+		{"ffffffff", time.Date(2040, 2, 6, 6, 28, 15, 0, time.UTC), nil},
+		{"", time.Time{}, io.EOF},
+		{"cbdf34", time.Time{}, io.ErrUnexpectedEOF},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		got, err := typeio.ReadHFSPlusTime(bytes.NewReader(b))
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%q: error expected: got %v", tc.b, got)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%q: unexpected type of error: got %q, want %q", tc.b, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%q: unexpected error: %s", tc.b, err)
+		case tc.e == nil && !got.Equal(tc.t):
+			t.Errorf("%q: unexpected read: got %v, want %v", tc.b, got, tc.t)
+		case tc.e == nil && !loceq(got.Location(), time.UTC):
+			t.Errorf("%q: unexpected loc: got %s, want %s", tc.b, got.Location(), time.UTC)
+		}
+	}
+}
+
+func TestWriteHFSPlusTime(t *testing.T) {
+	locJST, _ := time.LoadLocation("Asia/Tokyo")
+	tcs := []struct {
+		t time.Time
+		b string
+		e error
+	}{
+		{time.Date(2012, 5, 20, 23, 53, 54, 999999999, time.UTC), "cbdf3492", nil},
+		{time.Date(2012, 5, 21, 8, 53, 54, 0, locJST), "cbdf3492", nil},
+		{time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC), "00000000", nil},
+		{time.Date(2040, 2, 6, 6, 28, 15, 0, time.UTC), "ffffffff", nil},
+		{time.Date(1903, 12, 31, 23, 59, 59, 999999999, time.UTC), "", typeio.ErrTimeOutOfRange},
+		{time.Date(2040, 2, 6, 6, 28, 16, 0, time.UTC), "", typeio.ErrTimeOutOfRange},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		err := typeio.WriteHFSPlusTime(w, tc.t)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%v: error expected.", tc.t)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%v: unexpected type of error: got %q, want %q", tc.t, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%v: unexpected error: %s", tc.t, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%v: unexpected write: got %s, want %s", tc.t, got, tc.b)
+		}
+	}
+}
+
+func TestHFSTime(t *testing.T) {
+	locJST, _ := time.LoadLocation("Asia/Tokyo")
+	b, _ := hex.DecodeString("cbdf3492")
+	got, err := typeio.ReadHFSTime(bytes.NewReader(b), locJST)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := time.Date(2012, 5, 20, 23, 53, 54, 0, locJST); !got.Equal(want) || !loceq(got.Location(), locJST) {
+		t.Errorf("unexpected read: got %v, want %v", got, want)
+	}
+	w := new(bytes.Buffer)
+	if err := typeio.WriteHFSTime(w, got); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := hex.EncodeToString(w.Bytes()); got != "cbdf3492" {
+		t.Errorf("unexpected write: got %s, want cbdf3492", got)
+	}
+	err = typeio.WriteHFSTime(w, time.Date(1903, 12, 31, 23, 59, 59, 0, time.UTC))
+	if !errors.Is(err, typeio.ErrTimeOutOfRange) {
+		t.Errorf("unexpected error: got %v, want %q", err, typeio.ErrTimeOutOfRange)
+	}
+}
+
+func TestReadGPSTime(t *testing.T) {
+	tcs := []struct {
+		b   string
+		tbl typeio.LeapSecondTable
+		t   time.Time
+		e   error
+	}{
+		{"069900015021", nil, time.Date(2012, 5, 20, 23, 53, 54, 0, time.UTC), nil},
+		{"000000000000", nil, time.Date(1980, 1, 6, 0, 0, 0, 0, time.UTC), nil},
+		{"078a00000010", nil, time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC), nil},
+		{"078a00000011", nil, time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), nil},
+		{"078a00000012", nil, time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), nil},
+		{
+			"069900015021", typeio.LeapSecondTable{{Offset: 19}},
+			time.Date(2012, 5, 20, 23, 54, 9, 0, time.UTC), nil,
+		},
+		{"000000093a80", nil, time.Time{}, typeio.ErrInvalidTime},
+		{"", nil, time.Time{}, io.EOF},
+		{"0699000150", nil, time.Time{}, io.ErrUnexpectedEOF},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		le := make([]byte, len(b))
+		copy(le, b)
+		if len(b) == 6 {
+			le[0], le[1] = b[1], b[0]
+			le[2], le[3], le[4], le[5] = b[5], b[4], b[3], b[2]
+		}
+		for i, rd := range []func() (time.Time, error){
+			func() (time.Time, error) { return typeio.ReadGPSTimeBE(bytes.NewReader(b), tc.tbl) },
+			func() (time.Time, error) { return typeio.ReadGPSTimeLE(bytes.NewReader(le), tc.tbl) },
+		} {
+			got, err := rd()
+			switch {
+			case tc.e != nil && err == nil:
+				t.Errorf("%q(%d): error expected: got %v", tc.b, i, got)
+			case tc.e != nil && !errors.Is(err, tc.e):
+				t.Errorf("%q(%d): unexpected type of error: got %q, want %q", tc.b, i, err, tc.e)
+			case tc.e == nil && err != nil:
+				t.Errorf("%q(%d): unexpected error: %s", tc.b, i, err)
+			case tc.e == nil && !got.Equal(tc.t):
+				t.Errorf("%q(%d): unexpected read: got %v, want %v", tc.b, i, got, tc.t)
+			case tc.e == nil && !loceq(got.Location(), time.UTC):
+				t.Errorf("%q(%d): unexpected loc: got %s, want %s", tc.b, i, got.Location(), time.UTC)
+			}
+		}
+	}
+}
+
+func TestWriteGPSTime(t *testing.T) {
+	tcs := []struct {
+		t   time.Time
+		tbl typeio.LeapSecondTable
+		be  string
+		le  string
+		e   error
+	}{
+		{time.Date(2012, 5, 20, 23, 53, 54, 500000000, time.UTC), nil, "069900015021", "990621500100", nil},
+		{time.Date(1980, 1, 6, 0, 0, 0, 0, time.UTC), nil, "000000000000", "000000000000", nil},
+		{time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), nil, "078a00000012", "8a0712000000", nil},
+		{time.Date(2012, 5, 20, 23, 54, 9, 0, time.UTC), typeio.LeapSecondTable{{Offset: 19}}, "069900015021", "990621500100", nil},
+		{time.Date(1980, 1, 5, 23, 59, 59, 0, time.UTC), nil, "", "", typeio.ErrTimeOutOfRange},
+		{time.Date(3300, 1, 1, 0, 0, 0, 0, time.UTC), nil, "", "", typeio.ErrTimeOutOfRange},
+	}
+	for _, tc := range tcs {
+		for i, wr := range []func(io.Writer, time.Time, typeio.LeapSecondTable) error{
+			typeio.WriteGPSTimeBE,
+			typeio.WriteGPSTimeLE,
+		} {
+			w := new(bytes.Buffer)
+			err := wr(w, tc.t, tc.tbl)
+			switch {
+			case tc.e != nil && err == nil:
+				t.Errorf("%v(%d): error expected.", tc.t, i)
+			case tc.e != nil && !errors.Is(err, tc.e):
+				t.Errorf("%v(%d): unexpected type of error: got %q, want %q", tc.t, i, err, tc.e)
+			case tc.e == nil && err != nil:
+				t.Errorf("%v(%d): unexpected error: %s", tc.t, i, err)
+			}
+			want := tc.be
+			if i == 1 {
+				want = tc.le
+			}
+			if got := hex.EncodeToString(w.Bytes()); got != want {
+				t.Errorf("%v(%d): unexpected write: got %s, want %s", tc.t, i, got, want)
+			}
+		}
+	}
+}
+
+func TestReadTAI64N(t *testing.T) {
+	tcs := []struct {
+		b   string
+		tbl typeio.LeapSecondTable
+		t   time.Time
+		e   error
+	}{
+		{"400000004fb98434075bcd15", nil, time.Date(2012, 5, 20, 23, 53, 54, 123456789, time.UTC), nil},
+		{"400000004fb9841c075bcd15", typeio.LeapSecondTable{{Offset: 10}}, time.Date(2012, 5, 20, 23, 53, 54, 123456789, time.UTC), nil},
+		{"40000000586846a300000000", nil, time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC), nil},
+		{"40000000586846a400000000", nil, time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), nil},
+		{"40000000586846a500000000", nil, time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), nil},
+		{"400000000000000a00000000", nil, time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), nil},
+		{"400000004fb984343b9aca00", nil, time.Time{}, typeio.ErrInvalidTime},
+		{"800000000000000000000000", nil, time.Time{}, typeio.ErrInvalidTime},
+		{"", nil, time.Time{}, io.EOF},
+		{"400000004fb98434075bcd", nil, time.Time{}, io.ErrUnexpectedEOF},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		got, err := typeio.ReadTAI64N(bytes.NewReader(b), tc.tbl)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%q: error expected: got %v", tc.b, got)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%q: unexpected type of error: got %q, want %q", tc.b, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%q: unexpected error: %s", tc.b, err)
+		case tc.e == nil && !got.Equal(tc.t):
+			t.Errorf("%q: unexpected read: got %v, want %v", tc.b, got, tc.t)
+		case tc.e == nil && !loceq(got.Location(), time.UTC):
+			t.Errorf("%q: unexpected loc: got %s, want %s", tc.b, got.Location(), time.UTC)
+		}
+		if len(b) < 8 {
+			continue
+		}
+		got, err = typeio.ReadTAI64(bytes.NewReader(b[:8]), tc.tbl)
+		if tc.e == nil && (err != nil || !got.Equal(tc.t.Truncate(time.Second))) {
+			t.Errorf("%q: unexpected TAI64 read: got %v, %v", tc.b, got, err)
+		}
+	}
+}
+
+func TestWriteTAI64N(t *testing.T) {
+	tcs := []struct {
+		t   time.Time
+		tbl typeio.LeapSecondTable
+		b   string
+		e   error
+	}{
+		{time.Date(2012, 5, 20, 23, 53, 54, 123456789, time.UTC), nil, "400000004fb98434075bcd15", nil},
+		{time.Date(2012, 5, 20, 23, 53, 54, 123456789, time.UTC), typeio.LeapSecondTable{{Offset: 10}}, "400000004fb9841c075bcd15", nil},
+		{time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), nil, "40000000586846a500000000", nil},
+		{time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), nil, "400000000000000a00000000", nil},
+		{time.Unix(1<<62, 0), nil, "", typeio.ErrTimeOutOfRange},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		err := typeio.WriteTAI64N(w, tc.t, tc.tbl)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%v: error expected.", tc.t)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%v: unexpected type of error: got %q, want %q", tc.t, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%v: unexpected error: %s", tc.t, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%v: unexpected write: got %s, want %s", tc.t, got, tc.b)
+		}
+		w.Reset()
+		err = typeio.WriteTAI64(w, tc.t, tc.tbl)
+		if got, want := hex.EncodeToString(w.Bytes()), tc.b; len(want) != 0 {
+			want = want[:16]
+			if err != nil || got != want {
+				t.Errorf("%v: unexpected TAI64 write: got %s, %v, want %s", tc.t, got, err, want)
+			}
+		} else if !errors.Is(err, tc.e) {
+			t.Errorf("%v: unexpected TAI64 error: got %v, want %q", tc.t, err, tc.e)
+		}
+	}
+}
+
+func TestReadDuration(t *testing.T) {
+	tcs := []struct {
+		b    string
+		unit time.Duration
+		size int
+		d    time.Duration
+		e    error
+	}{
+		{"000005dc", time.Millisecond, 4, 1500 * time.Millisecond, nil},
+		{"ffff", time.Second, 2, 65535 * time.Second, nil},
+		{"ff", 10 * time.Millisecond, 1, 2550 * time.Millisecond, nil},
+		{"00000000075bcd15", time.Microsecond, 8, 123456789 * time.Microsecond, nil},
+		{"7fffffffffffffff", time.Nanosecond, 8, math.MaxInt64, nil},
+		{"8000000000000000", time.Nanosecond, 8, 0, typeio.ErrOverflow},
+		{"0020c49ba5e353f8", time.Microsecond, 8, 0, typeio.ErrOverflow},
+		{"0020c49ba5e353f7", time.Microsecond, 8, 9223372036854775 * time.Microsecond, nil},
+		{"000000", time.Second, 3, 0, typeio.ErrInvalidSize},
+		{"00000000", 0, 4, 0, typeio.ErrValueOutOfRange},
+		{"", time.Second, 4, 0, io.EOF},
+		{"000005", time.Second, 4, 0, io.ErrUnexpectedEOF},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		rev := make([]byte, len(b))
+		for i := range b {
+			rev[len(b)-1-i] = b[i]
+		}
+		for i, rd := range []func() (time.Duration, error){
+			func() (time.Duration, error) { return typeio.ReadDurationBE(bytes.NewReader(b), tc.unit, tc.size) },
+			func() (time.Duration, error) { return typeio.ReadDurationLE(bytes.NewReader(rev), tc.unit, tc.size) },
+		} {
+			got, err := rd()
+			switch {
+			case tc.e != nil && err == nil:
+				t.Errorf("%q(%d): error expected: got %v", tc.b, i, got)
+			case tc.e != nil && !errors.Is(err, tc.e):
+				t.Errorf("%q(%d): unexpected type of error: got %q, want %q", tc.b, i, err, tc.e)
+			case tc.e == nil && err != nil:
+				t.Errorf("%q(%d): unexpected error: %s", tc.b, i, err)
+			case tc.e == nil && got != tc.d:
+				t.Errorf("%q(%d): unexpected read: got %v, want %v", tc.b, i, got, tc.d)
+			}
+		}
+	}
+}
+
+func TestWriteDuration(t *testing.T) {
+	tcs := []struct {
+		d    time.Duration
+		unit time.Duration
+		size int
+		b    string
+		e    error
+	}{
+		{1500 * time.Millisecond, time.Millisecond, 4, "000005dc", nil},
+		{1500*time.Millisecond + 999999, time.Millisecond, 4, "000005dc", nil},
+		{65535 * time.Second, time.Second, 2, "ffff", nil},
+		{65536 * time.Second, time.Second, 2, "", typeio.ErrValueOutOfRange},
+		{2550 * time.Millisecond, 10 * time.Millisecond, 1, "ff", nil},
+		{2560 * time.Millisecond, 10 * time.Millisecond, 1, "", typeio.ErrValueOutOfRange},
+		{math.MaxInt64, time.Nanosecond, 8, "7fffffffffffffff", nil},
+		{0, time.Second, 4, "00000000", nil},
+		{-1, time.Second, 4, "", typeio.ErrValueOutOfRange},
+		{time.Second, time.Second, 3, "", typeio.ErrInvalidSize},
+		{time.Second, -time.Second, 4, "", typeio.ErrValueOutOfRange},
+	}
+	for _, tc := range tcs {
+		for i, wr := range []func(io.Writer, time.Duration, time.Duration, int) error{
+			typeio.WriteDurationBE,
+			typeio.WriteDurationLE,
+		} {
+			w := new(bytes.Buffer)
+			err := wr(w, tc.d, tc.unit, tc.size)
+			switch {
+			case tc.e != nil && err == nil:
+				t.Errorf("%v(%d): error expected.", tc.d, i)
+			case tc.e != nil && !errors.Is(err, tc.e):
+				t.Errorf("%v(%d): unexpected type of error: got %q, want %q", tc.d, i, err, tc.e)
+			case tc.e == nil && err != nil:
+				t.Errorf("%v(%d): unexpected error: %s", tc.d, i, err)
+			}
+			got := w.Bytes()
+			if i == 1 {
+				for j := 0; j < len(got)/2; j++ {
+					got[j], got[len(got)-1-j] = got[len(got)-1-j], got[j]
+				}
+			}
+			if got := hex.EncodeToString(got); got != tc.b {
+				t.Errorf("%v(%d): unexpected write: got %s, want %s", tc.d, i, got, tc.b)
+			}
+		}
+	}
+}
+
+func TestDurationSecNanos(t *testing.T) {
+	tcs := []struct {
+		be string
+		le string
+		d  time.Duration
+		e  error
+	}{
+		{"0000000225c17d0432f2d7ff", "047dc12502000000ffd7f232", math.MaxInt64, nil},
+		{"fffffffdda3e82fccd0d2800", "fc823edafdffffff00280dcd", math.MinInt64, nil},
+		{"ffffffffffffffffe2329b00", "ffffffffffffffff009b32e2", -1500 * time.Millisecond, nil},
+		{"000000000000000000000000", "000000000000000000000000", 0, nil},
+		{"0000000225c17d0432f2d800", "047dc1250200000000d8f232", 0, typeio.ErrOverflow},
+		{"0000000225c17d0500000000", "057dc1250200000000000000", 0, typeio.ErrOverflow},
+		{"00000000000000013b9aca00", "0100000000000000009aca3b", 0, typeio.ErrInvalidTime},
+		{"ffffffffffffffff1dcd6500", "ffffffffffffffff0065cd1d", 0, typeio.ErrInvalidTime},
+		{"", "", 0, io.EOF},
+		{"000000000000000000000000"[:20], "000000000000000000000000"[:20], 0, io.ErrUnexpectedEOF},
+	}
+	for _, tc := range tcs {
+		for i, s := range []string{tc.be, tc.le} {
+			b, err := hex.DecodeString(s)
+			if err != nil {
+				t.Errorf("%q: invalid test data: %s", s, err)
+				continue
+			}
+			var got time.Duration
+			if i == 0 {
+				got, err = typeio.ReadDurationSecNanosBE(bytes.NewReader(b))
+			} else {
+				got, err = typeio.ReadDurationSecNanosLE(bytes.NewReader(b))
+			}
+			switch {
+			case tc.e != nil && err == nil:
+				t.Errorf("%q: error expected: got %v", s, got)
+			case tc.e != nil && !errors.Is(err, tc.e):
+				t.Errorf("%q: unexpected type of error: got %q, want %q", s, err, tc.e)
+			case tc.e == nil && err != nil:
+				t.Errorf("%q: unexpected error: %s", s, err)
+			case tc.e == nil && got != tc.d:
+				t.Errorf("%q: unexpected read: got %v, want %v", s, got, tc.d)
+			}
+		}
+		if tc.e != nil {
+			continue
+		}
+		w := new(bytes.Buffer)
+		if err := typeio.WriteDurationSecNanosBE(w, tc.d); err != nil {
+			t.Errorf("%v: unexpected error: %s", tc.d, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.be {
+			t.Errorf("%v: unexpected write: got %s, want %s", tc.d, got, tc.be)
+		}
+		w.Reset()
+		if err := typeio.WriteDurationSecNanosLE(w, tc.d); err != nil {
+			t.Errorf("%v: unexpected error: %s", tc.d, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.le {
+			t.Errorf("%v: unexpected write: got %s, want %s", tc.d, got, tc.le)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"time"
+)
+
+// TimeCodec describes a timestamp format that represents a time as an integer
+// count of a fixed unit elapsed since an epoch, and reads and writes time
+// values in that format. It allows handling vendor specific formats, such as
+// the number of milliseconds elapsed since Jan 1, 2000, without a dedicated
+// function. The zero value is not usable; Unit and Size must be set.
+type TimeCodec struct {
+	// Epoch is the time represented by the value 0.
+	Epoch time.Time
+
+	// Unit is the duration represented by the value 1. It must be positive.
+	Unit time.Duration
+
+	// Size is the number of bytes of the value, either 4 or 8.
+	Size int
+
+	// Signed specifies whether the value is a signed integer. If false, times
+	// before Epoch can not be represented.
+	Signed bool
+
+	// ByteOrder is the byte order of the value. If nil, binary.BigEndian is
+	// used.
+	ByteOrder binary.ByteOrder
+
+	// Location is the location set to the time values returned by Read. If
+	// nil, UTC is used. It does not affect the values written by Write.
+	Location *time.Location
+}
+
+// Predefined TimeCodec values for the formats that have dedicated functions.
+// Unlike WriteUnixTime32BE and WriteUnixTime32LE, the 32-bit codecs return
+// ErrTimeOutOfRange for time values that do not fit in the format.
+var (
+	// UnixTime32BE is the 32-bit unsigned UNIX time in big-endian byte order,
+	// as read by ReadUnixTimeUTC32BE and written by WriteUnixTimeUint32BE.
+	UnixTime32BE = TimeCodec{Epoch: unixEpoch, Unit: time.Second, Size: 4, ByteOrder: binary.BigEndian}
+
+	// UnixTime32LE is the little-endian version of UnixTime32BE.
+	UnixTime32LE = TimeCodec{Epoch: unixEpoch, Unit: time.Second, Size: 4, ByteOrder: binary.LittleEndian}
+
+	// UnixTime64BE is the 64-bit signed UNIX time in big-endian byte order,
+	// as read by ReadUnixTimeUTC64BE and written by WriteUnixTime64BE.
+	UnixTime64BE = TimeCodec{Epoch: unixEpoch, Unit: time.Second, Size: 8, Signed: true, ByteOrder: binary.BigEndian}
+
+	// UnixTime64LE is the little-endian version of UnixTime64BE.
+	UnixTime64LE = TimeCodec{Epoch: unixEpoch, Unit: time.Second, Size: 8, Signed: true, ByteOrder: binary.LittleEndian}
+
+	// UnixMilli64BE is the 64-bit signed number of milliseconds elapsed since
+	// the UNIX epoch in big-endian byte order.
+	UnixMilli64BE = TimeCodec{Epoch: unixEpoch, Unit: time.Millisecond, Size: 8, Signed: true, ByteOrder: binary.BigEndian}
+
+	// UnixMilli64LE is the little-endian version of UnixMilli64BE.
+	UnixMilli64LE = TimeCodec{Epoch: unixEpoch, Unit: time.Millisecond, Size: 8, Signed: true, ByteOrder: binary.LittleEndian}
+
+	// UnixMicro64BE is the 64-bit signed number of microseconds elapsed since
+	// the UNIX epoch in big-endian byte order.
+	UnixMicro64BE = TimeCodec{Epoch: unixEpoch, Unit: time.Microsecond, Size: 8, Signed: true, ByteOrder: binary.BigEndian}
+
+	// UnixMicro64LE is the little-endian version of UnixMicro64BE.
+	UnixMicro64LE = TimeCodec{Epoch: unixEpoch, Unit: time.Microsecond, Size: 8, Signed: true, ByteOrder: binary.LittleEndian}
+
+	// UnixNano64BE is the 64-bit signed number of nanoseconds elapsed since the
+	// UNIX epoch in big-endian byte order.
+	UnixNano64BE = TimeCodec{Epoch: unixEpoch, Unit: time.Nanosecond, Size: 8, Signed: true, ByteOrder: binary.BigEndian}
+
+	// UnixNano64LE is the little-endian version of UnixNano64BE.
+	UnixNano64LE = TimeCodec{Epoch: unixEpoch, Unit: time.Nanosecond, Size: 8, Signed: true, ByteOrder: binary.LittleEndian}
+
+	// HFSPlusTime is the HFS+ date, as read by ReadHFSPlusTime and written by
+	// WriteHFSPlusTime.
+	HFSPlusTime = TimeCodec{Epoch: time.Unix(-hfsEpochOffset, 0).UTC(), Unit: time.Second, Size: 4, ByteOrder: binary.BigEndian}
+)
+
+// unixEpoch is the UNIX epoch, Jan 1, 1970 UTC.
+var unixEpoch = time.Unix(0, 0).UTC()
+
+// unixToInternal is the number of seconds from Jan 1, 0001, the origin of the
+// internal representation of time.Time, to the UNIX epoch. time.Time holds the
+// seconds since that origin in an int64, so a UNIX time greater than
+// math.MaxInt64-unixToInternal overflows it.
+const unixToInternal = (1969*365 + 1969/4 - 1969/100 + 1969/400) * 24 * 60 * 60
+
+// check returns an error if c is not a valid codec.
+func (c TimeCodec) check() error {
+	switch {
+	case c.Size != 4 && c.Size != 8:
+		return fmt.Errorf("%w: %d bytes for time codec", ErrInvalidSize, c.Size)
+	case c.Unit <= 0:
+		return fmt.Errorf("%w: time codec unit %v", ErrValueOutOfRange, c.Unit)
+	}
+	return nil
+}
+
+// order returns the byte order of c.
+func (c TimeCodec) order() binary.ByteOrder {
+	if c.ByteOrder == nil {
+		return binary.BigEndian
+	}
+	return c.ByteOrder
+}
+
+// Read reads Size bytes from r and returns the time they represent in the
+// format described by c. ErrTimeOutOfRange is returned if the value read
+// represents a time that can not be handled by time.Time.
+func (c TimeCodec) Read(r io.Reader) (time.Time, error) {
+	if err := c.check(); err != nil {
+		return time.Time{}, err
+	}
+	b, err := readN(r, c.Size)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var neg bool
+	var mag uint64
+	switch {
+	case c.Size == 4 && c.Signed:
+		v := int64(int32(c.order().Uint32(b)))
+		neg, mag = v < 0, uint64(v)
+	case c.Size == 4:
+		mag = uint64(c.order().Uint32(b))
+	case c.Signed:
+		v := int64(c.order().Uint64(b))
+		neg, mag = v < 0, uint64(v)
+	default:
+		mag = c.order().Uint64(b)
+	}
+	if neg {
+		mag = -mag
+	}
+
+	// mag * Unit in nanoseconds, split into seconds and nanoseconds
+	hi, lo := bits.Mul64(mag, uint64(c.Unit))
+	if 1e9 <= hi {
+		return time.Time{}, fmt.Errorf("%w: %d units of %v", ErrTimeOutOfRange, mag, c.Unit)
+	}
+	sec, nsec := bits.Div64(hi, lo, 1e9)
+	esec := c.Epoch.Unix()
+	var tsec int64
+	switch {
+	case math.MaxInt64 < sec:
+		return time.Time{}, fmt.Errorf("%w: %d units of %v", ErrTimeOutOfRange, mag, c.Unit)
+	case neg:
+		tsec = esec - int64(sec)
+		if esec < tsec {
+			return time.Time{}, fmt.Errorf("%w: -%d units of %v", ErrTimeOutOfRange, mag, c.Unit)
+		}
+		nsec = -nsec
+	default:
+		tsec = esec + int64(sec)
+		if tsec < esec || math.MaxInt64-unixToInternal < tsec {
+			return time.Time{}, fmt.Errorf("%w: %d units of %v", ErrTimeOutOfRange, mag, c.Unit)
+		}
+	}
+	t := time.Unix(tsec, int64(c.Epoch.Nanosecond())+int64(nsec))
+	if c.Location == nil {
+		return t.UTC(), nil
+	}
+	return t.In(c.Location), nil
+}
+
+// Write writes Size bytes to w that represent t in the format described by c.
+// Fractions smaller than Unit are truncated toward the past. The written bytes
+// do not depend on the location associated with t. ErrTimeOutOfRange is
+// returned if t does not fit in the format.
+func (c TimeCodec) Write(w io.Writer, t time.Time) error {
+	if err := c.check(); err != nil {
+		return err
+	}
+	tsec, esec := t.Unix(), c.Epoch.Unix()
+	dsec := tsec - esec
+	if (esec < 0 && dsec < tsec) || (0 < esec && tsec < dsec) {
+		return fmt.Errorf("%w: %v", ErrTimeOutOfRange, t)
+	}
+	dnsec := int64(t.Nanosecond()) - int64(c.Epoch.Nanosecond())
+	if dnsec < 0 {
+		dsec--
+		dnsec += 1e9
+		if dsec == math.MaxInt64 {
+			return fmt.Errorf("%w: %v", ErrTimeOutOfRange, t)
+		}
+	}
+
+	// the elapsed nanoseconds as a 128-bit magnitude, divided by Unit with
+	// the quotient rounded toward negative infinity
+	unit := uint64(c.Unit)
+	neg := dsec < 0
+	var hi, lo, borrow uint64
+	if neg {
+		hi, lo = bits.Mul64(uint64(-dsec), 1e9)
+		lo, borrow = bits.Sub64(lo, uint64(dnsec), 0)
+		hi -= borrow
+	} else {
+		hi, lo = bits.Mul64(uint64(dsec), 1e9)
+		lo, borrow = bits.Add64(lo, uint64(dnsec), 0)
+		hi += borrow
+	}
+	if unit <= hi {
+		return fmt.Errorf("%w: %v", ErrTimeOutOfRange, t)
+	}
+	mag, rem := bits.Div64(hi, lo, unit)
+	if neg && rem != 0 {
+		if mag++; mag == 0 {
+			return fmt.Errorf("%w: %v", ErrTimeOutOfRange, t)
+		}
+	}
+
+	var v uint64
+	switch {
+	case neg && !c.Signed,
+		neg && mag > 1<<(c.Size*8-1),
+		!neg && c.Signed && mag >= 1<<(c.Size*8-1),
+		!neg && c.Size == 4 && math.MaxUint32 < mag:
+		return fmt.Errorf("%w: %v", ErrTimeOutOfRange, t)
+	case neg:
+		v = -mag
+	default:
+		v = mag
+	}
+	b := make([]byte, c.Size)
+	if c.Size == 4 {
+		c.order().PutUint32(b, uint32(v))
+	} else {
+		c.order().PutUint64(b, v)
+	}
+	return write(w, b)
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func ExampleTimeCodec() {
+	// A vendor specific format: 64-bit unsigned number of milliseconds
+	// elapsed since Jan 1, 2000 UTC in little-endian byte order.
+	codec := typeio.TimeCodec{
+		Epoch:     time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		Unit:      time.Millisecond,
+		Size:      8,
+		ByteOrder: binary.LittleEndian,
+	}
+
+	w := new(bytes.Buffer)
+	if err := codec.Write(w, time.Date(2012, 5, 20, 23, 53, 54, 123456789, time.UTC)); err != nil {
+		panic(err)
+	}
+	fmt.Println(hex.EncodeToString(w.Bytes()))
+
+	t, err := codec.Read(w)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(t)
+
+	// Output:
+	// cb3adc015b000000
+	// 2012-05-20 23:53:54.123 +0000 UTC
+}
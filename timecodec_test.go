@@ -0,0 +1,170 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/tunabay/go-typeio"
+)
+
+var (
+	epoch2000 = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	codecMilli2000 = typeio.TimeCodec{Epoch: epoch2000, Unit: time.Millisecond, Size: 8, ByteOrder: binary.LittleEndian}
+	codecSec2000   = typeio.TimeCodec{Epoch: epoch2000, Unit: time.Second, Size: 4, ByteOrder: binary.LittleEndian}
+	codecTick2000  = typeio.TimeCodec{Epoch: epoch2000, Unit: 10 * time.Millisecond, Size: 4, Signed: true}
+)
+
+func TestTimeCodec_Read(t *testing.T) {
+	locJST, _ := time.LoadLocation("Asia/Tokyo")
+	tcs := []struct {
+		c   typeio.TimeCodec
+		b   string
+		t   time.Time
+		loc *time.Location
+		e   error
+	}{
+		{codecMilli2000, "cb3adc015b000000", time.Date(2012, 5, 20, 23, 53, 54, 123000000, time.UTC), time.UTC, nil},
+		{codecSec2000, "92404c17", time.Date(2012, 5, 20, 23, 53, 54, 0, time.UTC), time.UTC, nil},
+		{codecSec2000, "ffffffff", time.Date(2136, 2, 7, 6, 28, 15, 0, time.UTC), time.UTC, nil},
+		{codecTick2000, "ffffffff", time.Date(1999, 12, 31, 23, 59, 59, 990000000, time.UTC), time.UTC, nil},
+		{codecTick2000, "80000000", time.Date(1999, 12, 31, 23, 59, 59, 990000000, time.UTC).Add(-(1<<31 - 1) * 10 * time.Millisecond), time.UTC, nil},
+		{
+			typeio.TimeCodec{Epoch: epoch2000, Unit: time.Second, Size: 4, Location: locJST},
+			"174c4092", time.Date(2012, 5, 21, 8, 53, 54, 0, locJST), locJST, nil,
+		},
+		{
+			typeio.TimeCodec{Epoch: epoch2000.Add(time.Nanosecond), Unit: time.Second, Size: 8, Signed: true},
+			"ffffffffffffffff", time.Date(1999, 12, 31, 23, 59, 59, 1, time.UTC), time.UTC, nil,
+		},
+		{typeio.UnixTime64BE, "7fffffffffffffff", time.Time{}, nil, typeio.ErrTimeOutOfRange},
+		{typeio.TimeCodec{Unit: time.Hour, Size: 8}, "ffffffffffffffff", time.Time{}, nil, typeio.ErrTimeOutOfRange},
+		{typeio.TimeCodec{Unit: time.Second, Size: 2}, "0000", time.Time{}, nil, typeio.ErrInvalidSize},
+		{typeio.TimeCodec{Size: 4}, "00000000", time.Time{}, nil, typeio.ErrValueOutOfRange},
+		{codecSec2000, "", time.Time{}, nil, io.EOF},
+		{codecSec2000, "92404c", time.Time{}, nil, io.ErrUnexpectedEOF},
+	}
+	for _, tc := range tcs {
+		b, err := hex.DecodeString(tc.b)
+		if err != nil {
+			t.Errorf("%q: invalid test data: %s", tc.b, err)
+			continue
+		}
+		got, err := tc.c.Read(bytes.NewReader(b))
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%q: error expected: got %v", tc.b, got)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%q: unexpected type of error: got %q, want %q", tc.b, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%q: unexpected error: %s", tc.b, err)
+		case tc.e == nil && !got.Equal(tc.t):
+			t.Errorf("%q: unexpected read: got %v, want %v", tc.b, got, tc.t)
+		case tc.e == nil && !loceq(got.Location(), tc.loc):
+			t.Errorf("%q: unexpected loc: got %s, want %s", tc.b, got.Location(), tc.loc)
+		}
+	}
+}
+
+func TestTimeCodec_Write(t *testing.T) {
+	tcs := []struct {
+		c typeio.TimeCodec
+		t time.Time
+		b string
+		e error
+	}{
+		{codecMilli2000, time.Date(2012, 5, 20, 23, 53, 54, 123999999, time.UTC), "cb3adc015b000000", nil},
+		{codecSec2000, time.Date(2012, 5, 20, 23, 53, 54, 999999999, time.UTC), "92404c17", nil},
+		{codecSec2000, time.Date(2136, 2, 7, 6, 28, 15, 0, time.UTC), "ffffffff", nil},
+		{codecSec2000, time.Date(2136, 2, 7, 6, 28, 16, 0, time.UTC), "", typeio.ErrTimeOutOfRange},
+		{codecSec2000, time.Date(1999, 12, 31, 23, 59, 59, 999999999, time.UTC), "", typeio.ErrTimeOutOfRange},
+		{codecTick2000, time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), "00000000", nil},
+		{codecTick2000, time.Date(1999, 12, 31, 23, 59, 59, 995000000, time.UTC), "ffffffff", nil},
+		{codecTick2000, time.Date(1999, 12, 31, 23, 59, 59, 990000000, time.UTC), "ffffffff", nil},
+		{codecTick2000, time.Date(1999, 12, 31, 23, 59, 59, 985000000, time.UTC), "fffffffe", nil},
+		{codecTick2000, time.Date(1999, 12, 31, 23, 59, 59, 990000000, time.UTC).Add(-(1<<31 - 1) * 10 * time.Millisecond), "80000000", nil},
+		{codecTick2000, time.Date(1999, 12, 31, 23, 59, 59, 980000000, time.UTC).Add(-(1<<31 - 1) * 10 * time.Millisecond), "", typeio.ErrTimeOutOfRange},
+		{codecTick2000, epoch2000.Add((1<<31 - 1) * 10 * time.Millisecond), "7fffffff", nil},
+		{codecTick2000, epoch2000.Add(1 << 31 * 10 * time.Millisecond), "", typeio.ErrTimeOutOfRange},
+		{
+			typeio.TimeCodec{Epoch: epoch2000.Add(time.Nanosecond), Unit: time.Second, Size: 8, Signed: true},
+			time.Date(1999, 12, 31, 23, 59, 59, 999999999, time.UTC), "ffffffffffffffff", nil,
+		},
+		{typeio.UnixNano64BE, time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC), "", typeio.ErrTimeOutOfRange},
+		{typeio.UnixNano64BE, time.Date(1600, 1, 1, 0, 0, 0, 0, time.UTC), "", typeio.ErrTimeOutOfRange},
+		{typeio.TimeCodec{Unit: time.Second, Size: 2}, epoch2000, "", typeio.ErrInvalidSize},
+		{typeio.TimeCodec{Unit: -time.Second, Size: 4}, epoch2000, "", typeio.ErrValueOutOfRange},
+	}
+	for _, tc := range tcs {
+		w := new(bytes.Buffer)
+		err := tc.c.Write(w, tc.t)
+		switch {
+		case tc.e != nil && err == nil:
+			t.Errorf("%v: error expected.", tc.t)
+		case tc.e != nil && !errors.Is(err, tc.e):
+			t.Errorf("%v: unexpected type of error: got %q, want %q", tc.t, err, tc.e)
+		case tc.e == nil && err != nil:
+			t.Errorf("%v: unexpected error: %s", tc.t, err)
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != tc.b {
+			t.Errorf("%v: unexpected write: got %s, want %s", tc.t, got, tc.b)
+		}
+	}
+}
+
+func TestTimeCodec_predefined(t *testing.T) {
+	type fns struct {
+		name string
+		c    typeio.TimeCodec
+		rd   func(io.Reader) (time.Time, error)
+		wr   func(io.Writer, time.Time) error
+	}
+	tcs := []fns{
+		{"UnixTime32BE", typeio.UnixTime32BE, typeio.ReadUnixTimeUTC32BE, typeio.WriteUnixTimeUint32BE},
+		{"UnixTime32LE", typeio.UnixTime32LE, typeio.ReadUnixTimeUTC32LE, typeio.WriteUnixTimeUint32LE},
+		{"UnixTime64BE", typeio.UnixTime64BE, typeio.ReadUnixTimeUTC64BE, typeio.WriteUnixTime64BE},
+		{"UnixTime64LE", typeio.UnixTime64LE, typeio.ReadUnixTimeUTC64LE, typeio.WriteUnixTime64LE},
+		{"UnixMilli64BE", typeio.UnixMilli64BE, typeio.ReadUnixMilliUTC64BE, typeio.WriteUnixMilli64BE},
+		{"UnixMilli64LE", typeio.UnixMilli64LE, typeio.ReadUnixMilliUTC64LE, typeio.WriteUnixMilli64LE},
+		{"UnixMicro64BE", typeio.UnixMicro64BE, typeio.ReadUnixMicroUTC64BE, typeio.WriteUnixMicro64BE},
+		{"UnixMicro64LE", typeio.UnixMicro64LE, typeio.ReadUnixMicroUTC64LE, typeio.WriteUnixMicro64LE},
+		{"UnixNano64BE", typeio.UnixNano64BE, typeio.ReadUnixNanoUTC64BE, typeio.WriteUnixNano64BE},
+		{"UnixNano64LE", typeio.UnixNano64LE, typeio.ReadUnixNanoUTC64LE, typeio.WriteUnixNano64LE},
+		{"HFSPlusTime", typeio.HFSPlusTime, typeio.ReadHFSPlusTime, typeio.WriteHFSPlusTime},
+	}
+	times := []time.Time{
+		time.Date(2012, 5, 20, 23, 53, 54, 123456789, time.UTC),
+		time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1969, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2038, 1, 19, 3, 14, 8, 0, time.UTC),
+		time.Date(2106, 2, 7, 6, 28, 16, 0, time.UTC),
+	}
+	for _, tc := range tcs {
+		for _, tm := range times {
+			w1, w2 := new(bytes.Buffer), new(bytes.Buffer)
+			err1, err2 := tc.c.Write(w1, tm), tc.wr(w2, tm)
+			if (err1 == nil) != (err2 == nil) || !bytes.Equal(w1.Bytes(), w2.Bytes()) {
+				t.Errorf("%s: %v: write mismatch: codec %x, %v; func %x, %v", tc.name, tm, w1.Bytes(), err1, w2.Bytes(), err2)
+				continue
+			}
+			if err1 != nil {
+				continue
+			}
+			got1, err1 := tc.c.Read(bytes.NewReader(w1.Bytes()))
+			got2, err2 := tc.rd(bytes.NewReader(w1.Bytes()))
+			if err1 != nil || err2 != nil || !got1.Equal(got2) || !loceq(got1.Location(), got2.Location()) {
+				t.Errorf("%s: %x: read mismatch: codec %v, %v; func %v, %v", tc.name, w1.Bytes(), got1, err1, got2, err2)
+			}
+		}
+	}
+}
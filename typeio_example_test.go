@@ -21,7 +21,7 @@ func Example_usage() {
 		_ = typeio.WriteUint32BE(w, 12345678)
 		_ = typeio.WriteFloat64BE(w, math.Pi)
 		_ = typeio.WriteUnixTime32BE(w, time.Date(2012, 5, 20, 23, 53, 54, 0, time.UTC))
-		_, _ = w.Write([]byte("null-terminated string.\x00"))
+		_ = typeio.WriteCString(w, "null-terminated string.")
 		_ = typeio.WriteIPv4(w, net.ParseIP("192.0.2.1"))
 		w.Close()
 	}()
@@ -12,13 +12,77 @@ import (
 
 func readN(r io.Reader, n int) ([]byte, error) {
 	b := make([]byte, n)
+	if err := readFull(r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// readFull reads exactly len(b) bytes from r into b. It returns io.EOF only if
+// no bytes were read, otherwise a wrapped io.ErrUnexpectedEOF or the error
+// returned by r.
+func readFull(r io.Reader, b []byte) error {
 	if _, err := io.ReadFull(r, b); err != nil {
 		if errors.Is(err, io.EOF) {
-			return nil, io.EOF
+			return io.EOF
 		}
-		return nil, fmt.Errorf("read failure: %w", err)
+		return fmt.Errorf("read failure: %w", err)
 	}
-	return b, nil
+	return nil
+}
+
+// readByte reads a single byte from r, using io.ByteReader if r implements it.
+// As with readN, it returns io.EOF if no byte is available.
+func readByte(r io.Reader) (byte, error) {
+	if br, ok := r.(io.ByteReader); ok {
+		c, err := br.ReadByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return 0, io.EOF
+			}
+			return 0, fmt.Errorf("read failure: %w", err)
+		}
+		return c, nil
+	}
+	var b [1]byte
+	if err := readFull(r, b[:]); err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+// decodeN splits b into the first n bytes and the rest. As with readN, it
+// returns io.EOF if b is empty, and an error wrapping io.ErrUnexpectedEOF if b
+// is shorter than n bytes.
+func decodeN(b []byte, n int) ([]byte, []byte, error) {
+	switch {
+	case len(b) == 0 && n != 0:
+		return nil, b, io.EOF
+	case len(b) < n:
+		return nil, b, fmt.Errorf("%w: %d bytes required, %d available", io.ErrUnexpectedEOF, n, len(b))
+	}
+	return b[:n:n], b[n:], nil
+}
+
+// readDecode reads n bytes from r and decodes them with decode. It allows the
+// Read functions to share the implementation with the Decode functions.
+func readDecode[T any](r io.Reader, n int, decode func([]byte) (T, []byte, error)) (T, error) {
+	b, err := readN(r, n)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	v, _, err := decode(b)
+	return v, err
+}
+
+// noEOF converts io.EOF returned by readN, readFull or readByte in the middle
+// of a value into io.ErrUnexpectedEOF.
+func noEOF(err error) error {
+	if err == io.EOF { //nolint:errorlint // readN returns unwrapped io.EOF
+		return fmt.Errorf("read failure: %w", io.ErrUnexpectedEOF)
+	}
+	return err
 }
 
 func write(w io.Writer, b []byte) error {
@@ -0,0 +1,221 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"time"
+)
+
+// Writer wraps an io.Writer and writes values of primitive data types to it in
+// the configured byte order.
+//
+// Unlike the Write* functions, the methods of Writer do not return an error.
+// Instead, the first error encountered is recorded in the Writer, and all
+// subsequent calls do nothing. This allows writing a whole record with a series
+// of method calls and checking the error only once at the end with Err or
+// Flush. Values are encoded into an internal buffer, so writing a value does
+// not allocate.
+type Writer struct {
+	w     io.Writer
+	order binary.ByteOrder
+	err   error
+	n     int64
+	buf   [8]byte
+}
+
+// NewWriter returns a new Writer writing to w in the byte order order. If
+// order is nil, binary.BigEndian is used.
+func NewWriter(w io.Writer, order binary.ByteOrder) *Writer {
+	if order == nil {
+		order = binary.BigEndian
+	}
+	return &Writer{w: w, order: order}
+}
+
+// ByteOrder returns the byte order currently used by w.
+func (w *Writer) ByteOrder() binary.ByteOrder {
+	return w.order
+}
+
+// SetByteOrder changes the byte order used by w for subsequent writes. If
+// order is nil, binary.BigEndian is used.
+func (w *Writer) SetByteOrder(order binary.ByteOrder) {
+	if order == nil {
+		order = binary.BigEndian
+	}
+	w.order = order
+}
+
+// Err returns the first error encountered by w, or nil if no error has
+// occurred.
+func (w *Writer) Err() error {
+	return w.err
+}
+
+// Count returns the total number of bytes successfully written to the
+// underlying io.Writer.
+func (w *Writer) Count() int64 {
+	return w.n
+}
+
+// Flush flushes the underlying io.Writer if it has a Flush method, such as
+// bufio.Writer, and returns the first error encountered by w.
+func (w *Writer) Flush() error {
+	if w.err != nil {
+		return w.err
+	}
+	if f, ok := w.w.(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			w.err = fmt.Errorf("flush failure: %w", err)
+		}
+	}
+	return w.err
+}
+
+// Write writes p to the underlying io.Writer. It implements io.Writer, so that
+// w can be passed to the Write* functions. If an error has already occurred, it
+// returns the error without writing anything.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	return w.write(p), w.err
+}
+
+// write writes b to the underlying io.Writer, records the error if any, and
+// returns the number of bytes written.
+func (w *Writer) write(b []byte) int {
+	if w.err != nil {
+		return 0
+	}
+	n, err := w.w.Write(b)
+	w.n += int64(n)
+	if err != nil {
+		w.err = fmt.Errorf("write failure: %w", err)
+	}
+	return n
+}
+
+// Uint8 writes 1 byte that represents the value v of uint8.
+func (w *Writer) Uint8(v uint8) {
+	w.buf[0] = v
+	w.write(w.buf[:1])
+}
+
+// Int8 writes 1 byte that represents the value v of int8.
+func (w *Writer) Int8(v int8) {
+	w.Uint8(uint8(v))
+}
+
+// Uint16 writes 2 bytes that represent the value v of uint16.
+func (w *Writer) Uint16(v uint16) {
+	w.order.PutUint16(w.buf[:2], v)
+	w.write(w.buf[:2])
+}
+
+// Int16 writes 2 bytes that represent the value v of int16.
+func (w *Writer) Int16(v int16) {
+	w.Uint16(uint16(v))
+}
+
+// Uint32 writes 4 bytes that represent the value v of uint32.
+func (w *Writer) Uint32(v uint32) {
+	w.order.PutUint32(w.buf[:4], v)
+	w.write(w.buf[:4])
+}
+
+// Int32 writes 4 bytes that represent the value v of int32.
+func (w *Writer) Int32(v int32) {
+	w.Uint32(uint32(v))
+}
+
+// Uint64 writes 8 bytes that represent the value v of uint64.
+func (w *Writer) Uint64(v uint64) {
+	w.order.PutUint64(w.buf[:8], v)
+	w.write(w.buf[:8])
+}
+
+// Int64 writes 8 bytes that represent the value v of int64.
+func (w *Writer) Int64(v int64) {
+	w.Uint64(uint64(v))
+}
+
+// Float32 writes 4 bytes that represent the IEEE 754 float32 value v.
+func (w *Writer) Float32(v float32) {
+	w.Uint32(math.Float32bits(v))
+}
+
+// Float64 writes 8 bytes that represent the IEEE 754 float64 value v.
+func (w *Writer) Float64(v float64) {
+	w.Uint64(math.Float64bits(v))
+}
+
+// UnixTime32 writes 4 bytes that represent the 32-bit unsigned UNIX time for
+// t. As with WriteUnixTime32BE, time values that do not fit in the format are
+// not written correctly.
+func (w *Writer) UnixTime32(t time.Time) {
+	w.Uint32(uint32(t.Unix()))
+}
+
+// IPv4 writes 4 bytes that represent the IPv4 address addr. ErrInvalidIP is
+// recorded if addr is not a valid IPv4 address.
+func (w *Writer) IPv4(addr net.IP) {
+	if w.err != nil {
+		return
+	}
+	ip4 := addr.To4()
+	if ip4 == nil {
+		w.err = ErrInvalidIP
+		return
+	}
+	w.write(ip4)
+}
+
+// IPv6 writes 16 bytes that represent the IPv6 address addr. The addr can be
+// an IPv4 address, and it will be written as an IPv4-mapped IPv6. ErrInvalidIP
+// is recorded if addr is not a valid IP address.
+func (w *Writer) IPv6(addr net.IP) {
+	if w.err != nil {
+		return
+	}
+	ip16 := addr.To16()
+	if ip16 == nil {
+		w.err = ErrInvalidIP
+		return
+	}
+	w.write(ip16)
+}
+
+// Bytes writes b as is.
+func (w *Writer) Bytes(b []byte) {
+	w.write(b)
+}
+
+// StringN writes s as exactly n bytes, padding the rest with pad. See
+// WriteStringN for details.
+func (w *Writer) StringN(s string, n int, pad byte) {
+	if w.err != nil {
+		return
+	}
+	if err := WriteStringN(w, s, n, pad); err != nil && w.err == nil {
+		w.err = err
+	}
+}
+
+// CString writes s followed by a null character. ErrInvalidCString is recorded
+// if s contains a null character.
+func (w *Writer) CString(s string) {
+	if w.err != nil {
+		return
+	}
+	if err := WriteCString(w, s); err != nil && w.err == nil {
+		w.err = err
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+
+	"github.com/tunabay/go-typeio"
+)
+
+func ExampleWriter() {
+	buf := new(bytes.Buffer)
+	w := typeio.NewWriter(buf, binary.LittleEndian)
+
+	w.Uint8(1)
+	w.Uint8(2)
+	w.Uint32(1234)
+	w.Uint64(12345678)
+	w.IPv4(net.ParseIP("192.0.2.1"))
+	w.Bytes([]byte("ABCD\x00"))
+	if err := w.Flush(); err != nil {
+		panic(err)
+	}
+	fmt.Println(w.Count())
+	fmt.Println(hex.EncodeToString(buf.Bytes()))
+
+	// Output:
+	// 23
+	// 0102d20400004e61bc0000000000c00002014142434400
+}
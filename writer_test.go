@@ -0,0 +1,159 @@
+// Copyright (c) 2021 Hirotsuna Mizuno. All rights reserved.
+// Use of this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package typeio_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/tunabay/go-typeio"
+)
+
+// limitedWriter is an io.Writer that fails after n bytes are written.
+type limitedWriter struct {
+	n int
+}
+
+var errLimitedWriter = errors.New("limited writer: no space left")
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if len(p) <= w.n {
+		w.n -= len(p)
+		return len(p), nil
+	}
+	n := w.n
+	w.n = 0
+	return n, errLimitedWriter
+}
+
+func TestWriter(t *testing.T) {
+	tcs := []struct {
+		order binary.ByteOrder
+		put   func(*typeio.Writer)
+		b     string
+	}{
+		{binary.BigEndian, func(w *typeio.Writer) { w.Uint8(0xff) }, "ff"},
+		{binary.BigEndian, func(w *typeio.Writer) { w.Int8(-128) }, "80"},
+		{binary.BigEndian, func(w *typeio.Writer) { w.Uint16(0x1234) }, "1234"},
+		{binary.LittleEndian, func(w *typeio.Writer) { w.Int16(-257) }, "fffe"},
+		{binary.BigEndian, func(w *typeio.Writer) { w.Uint32(0x12345678) }, "12345678"},
+		{binary.BigEndian, func(w *typeio.Writer) { w.Int32(-2) }, "fffffffe"},
+		{binary.LittleEndian, func(w *typeio.Writer) { w.Uint64(0x0807060504030201) }, "0102030405060708"},
+		{binary.BigEndian, func(w *typeio.Writer) { w.Int64(-2) }, "fffffffffffffffe"},
+		{binary.BigEndian, func(w *typeio.Writer) { w.Float32(3.141592653589793) }, "40490fdb"},
+		{binary.LittleEndian, func(w *typeio.Writer) { w.Float64(3.141592653589793) }, "182d4454fb210940"},
+		{
+			binary.BigEndian,
+			func(w *typeio.Writer) { w.UnixTime32(time.Date(2012, 5, 20, 23, 53, 54, 0, time.UTC)) },
+			"4fb98412",
+		},
+		{binary.BigEndian, func(w *typeio.Writer) { w.IPv4(net.ParseIP("192.0.2.1")) }, "c0000201"},
+		{
+			binary.BigEndian,
+			func(w *typeio.Writer) { w.IPv6(net.ParseIP("2001:db8::1234:5678")) },
+			"20010db8000000000000000012345678",
+		},
+		{binary.BigEndian, func(w *typeio.Writer) { w.Bytes([]byte("ABC")) }, "414243"},
+	}
+	for i, tc := range tcs {
+		buf := new(bytes.Buffer)
+		w := typeio.NewWriter(buf, tc.order)
+		tc.put(w)
+		if err := w.Flush(); err != nil {
+			t.Errorf("#%d: unexpected error: %s", i, err)
+			continue
+		}
+		if got := hex.EncodeToString(buf.Bytes()); got != tc.b {
+			t.Errorf("#%d: unexpected write: got %s, want %s", i, got, tc.b)
+		}
+		if got, want := w.Count(), int64(len(tc.b)/2); got != want {
+			t.Errorf("#%d: unexpected count: got %d, want %d", i, got, want)
+		}
+	}
+}
+
+func TestWriter_stickyError(t *testing.T) {
+	w := typeio.NewWriter(&limitedWriter{n: 5}, nil)
+	w.Uint32(0x12345678)
+	if err := w.Err(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	w.Uint16(0xabcd)
+	if err := w.Err(); !errors.Is(err, errLimitedWriter) {
+		t.Fatalf("unexpected error: got %v, want %v", err, errLimitedWriter)
+	}
+	w.Uint8(0)
+	w.IPv4(nil)
+	if err := w.Flush(); !errors.Is(err, errLimitedWriter) {
+		t.Errorf("error not sticky: got %v, want %v", err, errLimitedWriter)
+	}
+	if got := w.Count(); got != 5 {
+		t.Errorf("unexpected count: got %d, want 5", got)
+	}
+
+	w = typeio.NewWriter(new(bytes.Buffer), nil)
+	w.IPv4(net.ParseIP("2001:db8::1"))
+	w.Uint8(0)
+	if err := w.Err(); !errors.Is(err, typeio.ErrInvalidIP) {
+		t.Errorf("unexpected error: got %v, want %v", err, typeio.ErrInvalidIP)
+	}
+	if got := w.Count(); got != 0 {
+		t.Errorf("unexpected count: got %d, want 0", got)
+	}
+}
+
+func TestWriter_Flush(t *testing.T) {
+	buf := new(bytes.Buffer)
+	bw := bufio.NewWriter(buf)
+	w := typeio.NewWriter(bw, binary.LittleEndian)
+	w.Uint32(1)
+	if err := typeio.WriteUint16BE(w, 2); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("unexpected write before flush: %x", buf.Bytes())
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, want := hex.EncodeToString(buf.Bytes()), "010000000002"; got != want {
+		t.Errorf("unexpected write: got %s, want %s", got, want)
+	}
+}
+
+func TestWriter_allocs(t *testing.T) {
+	w := typeio.NewWriter(bufio.NewWriterSize(new(bytes.Buffer), 1<<16), nil)
+	allocs := testing.AllocsPerRun(100, func() {
+		w.Uint16(1)
+		w.Uint32(2)
+		w.Float64(3)
+	})
+	if allocs != 0 {
+		t.Errorf("unexpected allocations: %v", allocs)
+	}
+}
+
+func TestWriter_strings(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := typeio.NewWriter(buf, nil)
+	w.StringN("ABC", 6, ' ')
+	w.CString("Hello")
+	if err := w.Err(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, want := hex.EncodeToString(buf.Bytes()), "41424320202048656c6c6f00"; got != want {
+		t.Errorf("unexpected write: got %s, want %s", got, want)
+	}
+	w.CString("A\x00B")
+	if err := w.Err(); !errors.Is(err, typeio.ErrInvalidCString) {
+		t.Errorf("unexpected error: got %v, want %v", err, typeio.ErrInvalidCString)
+	}
+}